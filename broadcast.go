@@ -0,0 +1,222 @@
+package aegis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBroadcastCacheSize is the number of message IDs remembered for deduplication.
+	DefaultBroadcastCacheSize = 1024
+
+	// MaxBroadcastTTL is the largest hop count a broadcast may request.
+	MaxBroadcastTTL = 64
+
+	// broadcastForwardTimeout bounds how long a relay waits on its downstream peers.
+	broadcastForwardTimeout = 5 * time.Second
+
+	// maxConcurrentRelays bounds the number of broadcasts a node relays at once.
+	maxConcurrentRelays = 64
+)
+
+// BroadcastMessage is a message flooded across the mesh.
+type BroadcastMessage struct {
+	ID        string
+	OriginID  string
+	SenderID  string
+	TTL       int
+	Payload   []byte
+	Timestamp time.Time
+}
+
+// BroadcastHandler is called once for each broadcast message a node receives.
+type BroadcastHandler func(msg BroadcastMessage)
+
+// messageCache is a fixed-size set of recently seen message IDs, each with the
+// highest TTL it has arrived with. When full, the oldest ID is evicted.
+type messageCache struct {
+	seen  map[string]int32
+	order []string
+	next  int
+	mu    sync.Mutex
+}
+
+// newMessageCache creates a message cache holding up to size IDs.
+func newMessageCache(size int) *messageCache {
+	if size <= 0 {
+		size = DefaultBroadcastCacheSize
+	}
+	return &messageCache{
+		seen:  make(map[string]int32, size),
+		order: make([]string, size),
+	}
+}
+
+// Observe records an ID with the TTL it arrived with. It reports whether the ID
+// was not already present and whether ttl is higher than any TTL seen for it
+// before; a new ID always counts as higher.
+func (c *messageCache) Observe(id string, ttl int32) (isNew, higher bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if seen, exists := c.seen[id]; exists {
+		if ttl <= seen {
+			return false, false
+		}
+		c.seen[id] = ttl
+		return false, true
+	}
+
+	if evicted := c.order[c.next]; evicted != "" {
+		delete(c.seen, evicted)
+	}
+	c.order[c.next] = id
+	c.next = (c.next + 1) % len(c.order)
+	c.seen[id] = ttl
+
+	return true, true
+}
+
+// Len returns the number of IDs currently cached.
+func (c *messageCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.seen)
+}
+
+// OnBroadcast sets the handler invoked for received broadcast messages.
+func (n *Node) OnBroadcast(handler BroadcastHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.broadcastHandler = handler
+}
+
+// FloodBroadcast propagates a message across the whole mesh.
+// Each node delivers the message once and relays it to its own peers until
+// the TTL, measured in hops from this node, is exhausted. Returns the message ID.
+// If ctx is cancelled, ctx.Err() is returned and remaining peers are skipped.
+func (n *Node) FloodBroadcast(ctx context.Context, payload []byte, ttl int) (string, error) {
+	if n.PeerManager == nil || n.broadcastCache == nil {
+		return "", fmt.Errorf("peer manager or broadcast cache not initialized")
+	}
+	if ttl <= 0 || ttl > MaxBroadcastTTL {
+		return "", fmt.Errorf("broadcast TTL must be between 1 and %d, got %d", MaxBroadcastTTL, ttl)
+	}
+
+	id := NewID()
+
+	// Mark as seen so the message is not delivered back to us via a cycle.
+	n.broadcastCache.Observe(id, int32(ttl))
+
+	req := &BroadcastRequest{
+		MessageId: id,
+		OriginId:  n.ID,
+		SenderId:  n.ID,
+		Ttl:       int32(ttl),
+		Payload:   payload,
+		Timestamp: time.Now().Unix(),
+	}
+
+	var lastErr error
+	for _, peer := range n.GetAllPeers() {
+		if err := ctx.Err(); err != nil {
+			return id, err
+		}
+		if _, err := peer.Client.Broadcast(ctx, req); err != nil {
+			if ctx.Err() != nil {
+				return id, ctx.Err()
+			}
+			lastErr = fmt.Errorf("failed to broadcast to peer %s: %w", peer.Info.ID, err)
+		}
+	}
+
+	return id, lastErr
+}
+
+// receiveBroadcast delivers an incoming broadcast and relays it onward.
+// The handler runs only for the first copy of a message. A later copy that
+// arrived over a shorter path, and so carries a higher TTL, is relayed again so
+// nodes it can still reach are not cut off by the earlier, shorter-lived copy.
+// Relays are bounded by maxConcurrentRelays; when all are busy the incoming
+// call waits for one to free up, slowing down the sender.
+// Returns false if the message was already seen with at least this TTL, has
+// no hops left, or could not be relayed before ctx was done.
+func (n *Node) receiveBroadcast(ctx context.Context, req *BroadcastRequest) bool {
+	if n.broadcastCache == nil || n.relaySlots == nil {
+		return false
+	}
+
+	// The sender chooses the TTL, so bound it like a locally started broadcast.
+	if req.Ttl < 1 {
+		return false
+	}
+	ttl := min(req.Ttl, MaxBroadcastTTL)
+
+	isNew, higher := n.broadcastCache.Observe(req.MessageId, ttl)
+	if !higher {
+		return false
+	}
+
+	if isNew {
+		n.mu.RLock()
+		handler := n.broadcastHandler
+		n.mu.RUnlock()
+
+		if handler != nil {
+			handler(BroadcastMessage{
+				ID:        req.MessageId,
+				OriginID:  req.OriginId,
+				SenderID:  req.SenderId,
+				TTL:       int(ttl),
+				Payload:   req.Payload,
+				Timestamp: time.Unix(req.Timestamp, 0),
+			})
+		}
+	}
+
+	if ttl > 1 && n.PeerManager != nil {
+		forward := &BroadcastRequest{
+			MessageId: req.MessageId,
+			OriginId:  req.OriginId,
+			SenderId:  n.ID,
+			Ttl:       ttl - 1,
+			Payload:   req.Payload,
+			Timestamp: req.Timestamp,
+		}
+
+		select {
+		case n.relaySlots <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+		go func() {
+			defer func() { <-n.relaySlots }()
+			n.forwardBroadcast(RequestID(ctx), forward, req.SenderId)
+		}()
+	}
+
+	return true
+}
+
+// forwardBroadcast relays a broadcast to all peers except the sender and origin,
+// concurrently and under a single broadcastForwardTimeout.
+// Relays outlive the incoming call, so they carry its request ID on a fresh context.
+func (n *Node) forwardBroadcast(requestID string, req *BroadcastRequest, from string) {
+	ctx, cancel := context.WithTimeout(WithRequestID(context.Background(), requestID), broadcastForwardTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, peer := range n.GetAllPeers() {
+		if peer.Info.ID == from || peer.Info.ID == req.OriginId {
+			continue
+		}
+		wg.Add(1)
+		go func(client MeshServiceClient) {
+			defer wg.Done()
+			_, _ = client.Broadcast(ctx, req)
+		}(peer.Client)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,320 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// broadcastRecorder counts broadcast deliveries per node.
+type broadcastRecorder struct {
+	received map[string]int
+	mu       sync.Mutex
+}
+
+func (r *broadcastRecorder) handler(nodeID string) BroadcastHandler {
+	return func(msg BroadcastMessage) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.received[nodeID]++
+	}
+}
+
+func (r *broadcastRecorder) count(nodeID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.received[nodeID]
+}
+
+func newBroadcastChain(t *testing.T, length int, rec *broadcastRecorder) []*Node {
+	t.Helper()
+	nodes := make([]*Node, length)
+	for i := range nodes {
+		id := fmt.Sprintf("node-%d", i)
+		nodes[i] = NewNode(id, id, NodeTypeGeneric, fmt.Sprintf("localhost:%d", 9000+i))
+		nodes[i].OnBroadcast(rec.handler(id))
+		if i > 0 {
+			connectLoopback(nodes[i-1], nodes[i])
+		}
+	}
+	return nodes
+}
+
+func TestMessageCacheBounded(t *testing.T) {
+	cache := newMessageCache(3)
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if isNew, _ := cache.Observe(id, 1); !isNew {
+			t.Errorf("expected %s to be new", id)
+		}
+	}
+
+	if cache.Len() != 3 {
+		t.Errorf("expected cache size 3, got %d", cache.Len())
+	}
+	if isNew, _ := cache.Observe("d", 1); isNew {
+		t.Error("expected d to be a duplicate")
+	}
+	if isNew, _ := cache.Observe("a", 1); !isNew {
+		t.Error("expected a to have been evicted")
+	}
+}
+
+func TestMessageCacheObserveTracksHighestTTL(t *testing.T) {
+	cache := newMessageCache(4)
+
+	if isNew, higher := cache.Observe("a", 1); !isNew || !higher {
+		t.Errorf("expected first copy to be new, got %v, %v", isNew, higher)
+	}
+	if isNew, higher := cache.Observe("a", 3); isNew || !higher {
+		t.Errorf("expected higher TTL to be reported, got %v, %v", isNew, higher)
+	}
+	if isNew, higher := cache.Observe("a", 3); isNew || higher {
+		t.Errorf("expected equal TTL to be a duplicate, got %v, %v", isNew, higher)
+	}
+	if _, higher := cache.Observe("a", 2); higher {
+		t.Error("expected lower TTL to be a duplicate")
+	}
+}
+
+func TestFloodBroadcastInvalidTTL(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	for _, ttl := range []int{0, -1, MaxBroadcastTTL + 1} {
+		if _, err := node.FloodBroadcast(context.Background(), []byte("hello"), ttl); err == nil {
+			t.Errorf("expected error for TTL %d", ttl)
+		}
+	}
+}
+
+func TestFloodBroadcastMultiHop(t *testing.T) {
+	rec := &broadcastRecorder{received: make(map[string]int)}
+	nodes := newBroadcastChain(t, 4, rec)
+
+	_, err := nodes[0].FloodBroadcast(context.Background(), []byte("hello"), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && rec.count("node-3") == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if rec.count("node-0") != 0 {
+		t.Error("origin should not receive its own broadcast")
+	}
+	for _, id := range []string{"node-1", "node-2", "node-3"} {
+		if rec.count(id) != 1 {
+			t.Errorf("expected %s to receive broadcast once, got %d", id, rec.count(id))
+		}
+	}
+}
+
+func TestFloodBroadcastTTLLimitsHops(t *testing.T) {
+	rec := &broadcastRecorder{received: make(map[string]int)}
+	nodes := newBroadcastChain(t, 4, rec)
+
+	_, err := nodes[0].FloodBroadcast(context.Background(), []byte("hello"), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && rec.count("node-2") == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if rec.count("node-1") != 1 || rec.count("node-2") != 1 {
+		t.Errorf("expected nodes within 2 hops to receive, got %v", rec.received)
+	}
+	if rec.count("node-3") != 0 {
+		t.Error("node 3 hops away should not receive a TTL 2 broadcast")
+	}
+}
+
+func TestReceiveBroadcastDeduplicates(t *testing.T) {
+	rec := &broadcastRecorder{received: make(map[string]int)}
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.OnBroadcast(rec.handler(node.ID))
+
+	req := &BroadcastRequest{MessageId: "msg-1", OriginId: "other", SenderId: "other", Ttl: 1}
+
+	resp, err := node.MeshServer.Broadcast(context.Background(), req)
+	if err != nil || !resp.Accepted {
+		t.Fatalf("expected first delivery to be accepted, got %v, %v", resp, err)
+	}
+
+	resp, err = node.MeshServer.Broadcast(context.Background(), req)
+	if err != nil || resp.Accepted {
+		t.Fatalf("expected duplicate delivery to be rejected, got %v, %v", resp, err)
+	}
+
+	if rec.count(node.ID) != 1 {
+		t.Errorf("expected handler to run once, got %d", rec.count(node.ID))
+	}
+}
+
+func TestFloodBroadcastCancelled(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	calls := addBlockingPeers(node, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := node.FloodBroadcast(ctx, []byte("hello"), 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected prompt return, took %v", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected remaining peers to be skipped, got %d calls", n)
+	}
+}
+
+// TestReceiveBroadcastRelaysHigherTTLCopy covers a diamond where the origin
+// reaches X both directly and through B. If the longer path's copy arrives
+// first, X must still relay the direct copy so D, two hops from the origin,
+// receives the message.
+//
+//	origin -- B -- X -- D
+//	   \___________/
+func TestReceiveBroadcastRelaysHigherTTLCopy(t *testing.T) {
+	rec := &broadcastRecorder{received: make(map[string]int)}
+	b := NewNode("b", "B", NodeTypeGeneric, "localhost:9001")
+	x := NewNode("x", "X", NodeTypeGeneric, "localhost:9002")
+	d := NewNode("d", "D", NodeTypeGeneric, "localhost:9003")
+	for _, node := range []*Node{b, x, d} {
+		node.OnBroadcast(rec.handler(node.ID))
+	}
+	connectLoopback(b, x)
+	connectLoopback(x, d)
+
+	viaB := &BroadcastRequest{MessageId: "msg-1", OriginId: "origin", SenderId: "b", Ttl: 1}
+	direct := &BroadcastRequest{MessageId: "msg-1", OriginId: "origin", SenderId: "origin", Ttl: 2}
+
+	if resp, err := x.MeshServer.Broadcast(context.Background(), viaB); err != nil || !resp.Accepted {
+		t.Fatalf("expected first copy to be accepted, got %v, %v", resp, err)
+	}
+	if resp, err := x.MeshServer.Broadcast(context.Background(), direct); err != nil || !resp.Accepted {
+		t.Fatalf("expected higher TTL copy to be accepted, got %v, %v", resp, err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && rec.count("d") == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if rec.count("d") != 1 {
+		t.Errorf("expected D to receive the broadcast once, got %d", rec.count("d"))
+	}
+	if rec.count("x") != 1 {
+		t.Errorf("expected X to deliver the broadcast once, got %d", rec.count("x"))
+	}
+}
+
+func TestReceiveBroadcastCapsTTL(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	var got int
+	node.OnBroadcast(func(msg BroadcastMessage) { got = msg.TTL })
+
+	req := &BroadcastRequest{MessageId: "msg-1", OriginId: "other", SenderId: "other", Ttl: MaxBroadcastTTL * 100}
+	if !node.receiveBroadcast(context.Background(), req) {
+		t.Fatal("expected broadcast to be accepted")
+	}
+	if got != MaxBroadcastTTL {
+		t.Errorf("expected TTL capped at %d, got %d", MaxBroadcastTTL, got)
+	}
+}
+
+// requestIDClient reports the request ID of each relayed broadcast.
+type requestIDClient struct {
+	MeshServiceClient
+	ids chan string
+}
+
+func (c *requestIDClient) Broadcast(ctx context.Context, _ *BroadcastRequest, _ ...grpc.CallOption) (*BroadcastResponse, error) {
+	c.ids <- RequestID(ctx)
+	return &BroadcastResponse{Accepted: true}, nil
+}
+
+func TestReceiveBroadcastRelaysWithRequestID(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	client := &requestIDClient{ids: make(chan string, 1)}
+	node.PeerManager.peers["next"] = &Peer{Info: PeerInfo{ID: "next"}, Client: client}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	req := &BroadcastRequest{MessageId: "msg-1", OriginId: "origin", SenderId: "sender", Ttl: 2}
+	if _, err := node.MeshServer.Broadcast(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case id := <-client.ids:
+		if id != "req-1" {
+			t.Errorf("expected relay to carry request ID req-1, got %q", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("broadcast was not relayed")
+	}
+}
+
+func TestReceiveBroadcastDropsExhaustedTTL(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	var delivered []int
+	node.OnBroadcast(func(msg BroadcastMessage) { delivered = append(delivered, msg.TTL) })
+
+	for _, ttl := range []int32{0, -1} {
+		req := &BroadcastRequest{MessageId: "msg-1", OriginId: "other", SenderId: "other", Ttl: ttl}
+		if node.receiveBroadcast(context.Background(), req) {
+			t.Errorf("expected TTL %d to be dropped", ttl)
+		}
+	}
+	if len(delivered) != 0 {
+		t.Fatalf("expected no deliveries, got %v", delivered)
+	}
+
+	// The dropped copies must not mark the message as seen.
+	req := &BroadcastRequest{MessageId: "msg-1", OriginId: "other", SenderId: "other", Ttl: 1}
+	if !node.receiveBroadcast(context.Background(), req) {
+		t.Fatal("expected valid copy to be accepted")
+	}
+	if len(delivered) != 1 || delivered[0] != 1 {
+		t.Errorf("expected one delivery with TTL 1, got %v", delivered)
+	}
+}
+
+func TestReceiveBroadcastBoundsRelays(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	client := &requestIDClient{ids: make(chan string, 1)}
+	node.PeerManager.peers["next"] = &Peer{Info: PeerInfo{ID: "next"}, Client: client}
+
+	// Occupy every relay slot.
+	for range maxConcurrentRelays {
+		node.relaySlots <- struct{}{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req := &BroadcastRequest{MessageId: "msg-1", OriginId: "origin", SenderId: "sender", Ttl: 2}
+	if node.receiveBroadcast(ctx, req) {
+		t.Error("expected relay to be refused while all slots are busy")
+	}
+
+	select {
+	case <-client.ids:
+		t.Error("expected no relay while all slots are busy")
+	default:
+	}
+}
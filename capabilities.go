@@ -0,0 +1,107 @@
+package aegis
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+// Optional features a node may advertise through GetCapabilities.
+const (
+	FeatureBroadcast        = "broadcast"
+	FeatureHealthHistory    = "health-history"
+	FeatureTracing          = "tracing"
+	FeatureReplayProtection = "replay-protection"
+	FeatureTopologyStream   = "topology-stream"
+)
+
+// Capabilities describes the protocol version and optional features a node supports.
+type Capabilities struct {
+	ProtocolVersion int      `json:"protocol_version"`
+	Features        []string `json:"features"`
+}
+
+// Supports reports whether the feature is advertised.
+func (c Capabilities) Supports(feature string) bool {
+	return slices.Contains(c.Features, feature)
+}
+
+// Capabilities returns the capabilities this node advertises to peers.
+func (n *Node) Capabilities() Capabilities {
+	features := []string{FeatureBroadcast, FeatureHealthHistory, FeatureTopologyStream}
+
+	if n.MeshServer != nil {
+		if n.MeshServer.tracerProvider != nil {
+			features = append(features, FeatureTracing)
+		}
+		if n.MeshServer.replayGuard != nil {
+			features = append(features, FeatureReplayProtection)
+		}
+	}
+
+	return Capabilities{
+		ProtocolVersion: ProtocolVersion,
+		Features:        features,
+	}
+}
+
+// GetPeerCapabilities returns a peer's capabilities.
+// Results are cached per peer and refreshed after the connection is lost and re-established.
+func (pm *PeerManager) GetPeerCapabilities(ctx context.Context, peerID string) (Capabilities, error) {
+	pm.mu.RLock()
+	cached, ok := pm.capabilities[peerID]
+	pm.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	peer, exists := pm.GetPeer(peerID)
+	if !exists {
+		return Capabilities{}, fmt.Errorf("peer %s not found", peerID)
+	}
+
+	resp, err := peer.Client.GetCapabilities(ctx, &CapabilitiesRequest{SenderId: pm.nodeID})
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("failed to get capabilities from peer %s: %w", peerID, err)
+	}
+
+	caps := Capabilities{
+		ProtocolVersion: int(resp.ProtocolVersion),
+		Features:        resp.Features,
+	}
+
+	pm.mu.Lock()
+	if current, exists := pm.peers[peerID]; exists && current == peer {
+		pm.capabilities[peerID] = caps
+	}
+	pm.mu.Unlock()
+
+	return caps, nil
+}
+
+// invalidateCapabilitiesOnDisconnect drops a peer's cached capabilities whenever its
+// connection leaves READY, so the next lookup queries the peer again once reconnected.
+func (pm *PeerManager) invalidateCapabilitiesOnDisconnect(peer *Peer) {
+	state := peer.Conn.GetState()
+	for peer.Conn.WaitForStateChange(peer.ctx, state) {
+		next := peer.Conn.GetState()
+		if state == connectivity.Ready && next != connectivity.Ready {
+			pm.mu.Lock()
+			if pm.peers[peer.Info.ID] == peer {
+				delete(pm.capabilities, peer.Info.ID)
+			}
+			pm.mu.Unlock()
+		}
+		state = next
+	}
+}
+
+// GetPeerCapabilities returns a peer's protocol version and supported features.
+func (n *Node) GetPeerCapabilities(ctx context.Context, peerID string) (Capabilities, error) {
+	if n.PeerManager == nil {
+		return Capabilities{}, fmt.Errorf("peer manager not initialized")
+	}
+	return n.PeerManager.GetPeerCapabilities(ctx, peerID)
+}
@@ -0,0 +1,74 @@
+package aegis
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// capabilitiesClient counts GetCapabilities calls served by another node.
+type capabilitiesClient struct {
+	MeshServiceClient
+	server *MeshServer
+	calls  atomic.Int32
+}
+
+func (c *capabilitiesClient) GetCapabilities(ctx context.Context, req *CapabilitiesRequest, _ ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	c.calls.Add(1)
+	return c.server.GetCapabilities(ctx, req)
+}
+
+func TestNodeCapabilities(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	caps := node.Capabilities()
+	if caps.ProtocolVersion != ProtocolVersion {
+		t.Errorf("expected protocol version %d, got %d", ProtocolVersion, caps.ProtocolVersion)
+	}
+	if !caps.Supports(FeatureBroadcast) {
+		t.Error("expected broadcast to be supported")
+	}
+	if caps.Supports(FeatureReplayProtection) {
+		t.Error("expected replay protection to be unsupported by default")
+	}
+
+	node.MeshServer.SetReplayProtection(0, 0)
+	if !node.Capabilities().Supports(FeatureReplayProtection) {
+		t.Error("expected replay protection to be advertised once enabled")
+	}
+}
+
+func TestGetPeerCapabilitiesCached(t *testing.T) {
+	local := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	remote := NewNode("remote", "Remote", NodeTypeGeneric, "localhost:9002")
+
+	client := &capabilitiesClient{server: remote.MeshServer}
+	local.PeerManager.peers[remote.ID] = &Peer{
+		Info:   PeerInfo{ID: remote.ID, Address: remote.Address},
+		Client: client,
+	}
+
+	for range 3 {
+		caps, err := local.GetPeerCapabilities(context.Background(), remote.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !caps.Supports(FeatureHealthHistory) {
+			t.Errorf("expected health history to be supported, got %v", caps.Features)
+		}
+	}
+
+	if calls := client.calls.Load(); calls != 1 {
+		t.Errorf("expected 1 capabilities call, got %d", calls)
+	}
+}
+
+func TestGetPeerCapabilitiesNotFound(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	if _, err := node.GetPeerCapabilities(context.Background(), "missing"); err == nil {
+		t.Error("expected error for unknown peer")
+	}
+}
@@ -0,0 +1,217 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultCircuitFailureThreshold is the default number of consecutive failures that opens a breaker.
+	DefaultCircuitFailureThreshold = 5
+	// DefaultCircuitCooldown is the default time a breaker stays open before probing the peer.
+	DefaultCircuitCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without calling the peer while its circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitState represents the state of a peer's circuit breaker.
+type CircuitState string
+
+const (
+	// CircuitClosed allows calls through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fails calls fast until the cooldown elapses.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen allows a single probe call to test whether the peer recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitStateChange describes a circuit breaker transition for a peer.
+type CircuitStateChange struct {
+	PeerID string
+	From   CircuitState
+	To     CircuitState
+}
+
+// CircuitBreakerConfig configures per-peer circuit breaking.
+// Zero values select DefaultCircuitFailureThreshold and DefaultCircuitCooldown.
+type CircuitBreakerConfig struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+	OnStateChange    func(change CircuitStateChange)
+}
+
+// circuitBreaker tracks consecutive transient failures of calls to one peer.
+type circuitBreaker struct {
+	peerID   string
+	config   CircuitBreakerConfig
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	mu       sync.Mutex
+}
+
+// newCircuitBreaker creates a closed breaker for the peer.
+func newCircuitBreaker(peerID string, config CircuitBreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultCircuitCooldown
+	}
+	return &circuitBreaker{
+		peerID: peerID,
+		config: config,
+		state:  CircuitClosed,
+	}
+}
+
+// State returns the current breaker state.
+func (cb *circuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// allow reports whether a call may proceed, moving an open breaker to half-open
+// once the cooldown has elapsed. Only one probe is allowed while half-open; the
+// first return value reports whether this call is that probe.
+func (cb *circuitBreaker) allow() (bool, error) {
+	cb.mu.Lock()
+
+	var change *CircuitStateChange
+	probe := false
+	switch cb.state {
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.config.Cooldown {
+			cb.mu.Unlock()
+			return false, fmt.Errorf("%w: peer %s", ErrCircuitOpen, cb.peerID)
+		}
+		change = cb.transition(CircuitHalfOpen)
+		cb.probing = true
+		probe = true
+	case CircuitHalfOpen:
+		if cb.probing {
+			cb.mu.Unlock()
+			return false, fmt.Errorf("%w: peer %s", ErrCircuitOpen, cb.peerID)
+		}
+		cb.probing = true
+		probe = true
+	}
+
+	cb.mu.Unlock()
+	cb.notify(change)
+	return probe, nil
+}
+
+// record updates the breaker with the outcome of a call.
+// While the breaker is not closed only the probe's outcome counts; calls that
+// were already in flight when it opened are ignored.
+func (cb *circuitBreaker) record(err error, probe bool) {
+	cb.mu.Lock()
+
+	if cb.state != CircuitClosed && !probe {
+		cb.mu.Unlock()
+		return
+	}
+
+	var change *CircuitStateChange
+	if probe {
+		cb.probing = false
+	}
+
+	if isTransientFailure(err) {
+		cb.failures++
+		if cb.state == CircuitHalfOpen || (cb.state == CircuitClosed && cb.failures >= cb.config.FailureThreshold) {
+			change = cb.transition(CircuitOpen)
+			cb.openedAt = time.Now()
+		}
+	} else {
+		cb.failures = 0
+		if cb.state != CircuitClosed {
+			change = cb.transition(CircuitClosed)
+		}
+	}
+
+	cb.mu.Unlock()
+	cb.notify(change)
+}
+
+// transition changes state and returns the change to report. Callers must hold the lock.
+func (cb *circuitBreaker) transition(to CircuitState) *CircuitStateChange {
+	from := cb.state
+	cb.state = to
+	return &CircuitStateChange{PeerID: cb.peerID, From: from, To: to}
+}
+
+// notify reports a state change to the configured handler.
+func (cb *circuitBreaker) notify(change *CircuitStateChange) {
+	if change != nil && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(*change)
+	}
+}
+
+// isTransientFailure reports whether an error indicates the peer is unreachable or not responding.
+// Application errors returned by a reachable peer do not count against the breaker.
+// ResourceExhausted is excluded because it also reports oversized messages,
+// which fail the same way however often they are retried.
+func isTransientFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// unaryInterceptor fails fast while the breaker is open and records call outcomes.
+func (cb *circuitBreaker) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	probe, err := cb.allow()
+	if err != nil {
+		return err
+	}
+	err = invoker(ctx, method, req, reply, cc, opts...)
+	cb.record(err, probe)
+	return err
+}
+
+// streamInterceptor fails fast while the breaker is open and records stream establishment.
+func (cb *circuitBreaker) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	probe, err := cb.allow()
+	if err != nil {
+		return nil, err
+	}
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	cb.record(err, probe)
+	return stream, err
+}
+
+// SetCircuitBreaker enables a circuit breaker on calls to each peer.
+// Applies to peers added after the call.
+func (pm *PeerManager) SetCircuitBreaker(config CircuitBreakerConfig) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.circuitConfig = &config
+}
+
+// CircuitState returns the circuit breaker state for a peer.
+// Peers added without a circuit breaker always report CircuitClosed.
+func (pm *PeerManager) CircuitState(peerID string) (CircuitState, error) {
+	peer, exists := pm.GetPeer(peerID)
+	if !exists {
+		return "", fmt.Errorf("peer %s not found", peerID)
+	}
+	if peer.breaker == nil {
+		return CircuitClosed, nil
+	}
+	return peer.breaker.State(), nil
+}
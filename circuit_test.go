@@ -0,0 +1,178 @@
+package aegis
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func failingInvoker(code codes.Code) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if code == codes.OK {
+			return nil
+		}
+		return status.Error(code, "test")
+	}
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	var changes []CircuitStateChange
+	cb := newCircuitBreaker("peer-1", CircuitBreakerConfig{
+		FailureThreshold: 3,
+		Cooldown:         time.Hour,
+		OnStateChange:    func(c CircuitStateChange) { changes = append(changes, c) },
+	})
+
+	for range 3 {
+		_ = cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.Unavailable))
+	}
+
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected breaker to be open, got %s", cb.State())
+	}
+
+	called := false
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		called = true
+		return nil
+	}
+	err := cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, invoker)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if called {
+		t.Error("expected call to fail fast while open")
+	}
+
+	if len(changes) != 1 || changes[0].From != CircuitClosed || changes[0].To != CircuitOpen {
+		t.Errorf("expected closed->open change, got %+v", changes)
+	}
+}
+
+func TestCircuitBreakerIgnoresApplicationErrors(t *testing.T) {
+	cb := newCircuitBreaker("peer-1", CircuitBreakerConfig{FailureThreshold: 2})
+
+	for range 5 {
+		_ = cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.NotFound))
+	}
+
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected breaker to stay closed, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpenRecovery(t *testing.T) {
+	cb := newCircuitBreaker("peer-1", CircuitBreakerConfig{
+		FailureThreshold: 1,
+		Cooldown:         10 * time.Millisecond,
+	})
+
+	_ = cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.Unavailable))
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected breaker to be open, got %s", cb.State())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	// A failed probe reopens the breaker.
+	_ = cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.DeadlineExceeded))
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected failed probe to reopen breaker, got %s", cb.State())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.OK)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected successful probe to close breaker, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	cb := newCircuitBreaker("peer-1", CircuitBreakerConfig{
+		FailureThreshold: 1,
+		Cooldown:         time.Millisecond,
+	})
+
+	_ = cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.Unavailable))
+	time.Sleep(5 * time.Millisecond)
+
+	if probe, err := cb.allow(); err != nil || !probe {
+		t.Fatalf("expected probe to be allowed, got %v, %v", probe, err)
+	}
+	if _, err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected second call during probe to be rejected, got %v", err)
+	}
+}
+
+func TestCircuitBreakerIgnoresStaleResultsWhileProbing(t *testing.T) {
+	cb := newCircuitBreaker("peer-1", CircuitBreakerConfig{
+		FailureThreshold: 1,
+		Cooldown:         time.Millisecond,
+	})
+
+	// A call started while the breaker was still closed.
+	stale, err := cb.allow()
+	if err != nil || stale {
+		t.Fatalf("expected a regular call, got %v, %v", stale, err)
+	}
+
+	_ = cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.Unavailable))
+	time.Sleep(5 * time.Millisecond)
+
+	if probe, err := cb.allow(); err != nil || !probe {
+		t.Fatalf("expected probe to be allowed, got %v, %v", probe, err)
+	}
+
+	// The stale call finishing must neither close the breaker nor admit another probe.
+	cb.record(nil, stale)
+	if cb.State() != CircuitHalfOpen {
+		t.Errorf("expected breaker to stay half-open, got %s", cb.State())
+	}
+	if _, err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected second probe to be rejected, got %v", err)
+	}
+}
+
+func TestCircuitBreakerIgnoresResourceExhausted(t *testing.T) {
+	cb := newCircuitBreaker("peer-1", CircuitBreakerConfig{FailureThreshold: 2})
+
+	for range 5 {
+		_ = cb.unaryInterceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(codes.ResourceExhausted))
+	}
+
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected oversized messages not to open the breaker, got %s", cb.State())
+	}
+}
+
+func TestPeerManagerCircuitState(t *testing.T) {
+	pm := NewPeerManager("test-node")
+	pm.SetTLSConfig(&TLSConfig{CertPool: x509.NewCertPool()})
+	pm.SetCircuitBreaker(CircuitBreakerConfig{})
+	defer pm.Close()
+
+	if err := pm.AddPeer(PeerInfo{ID: "peer-1", Address: "localhost:1"}); err != nil {
+		t.Fatalf("failed to add peer: %v", err)
+	}
+
+	state, err := pm.CircuitState("peer-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state != CircuitClosed {
+		t.Errorf("expected closed breaker, got %s", state)
+	}
+
+	if _, err := pm.CircuitState("missing"); err == nil {
+		t.Error("expected error for unknown peer")
+	}
+}
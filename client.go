@@ -25,6 +25,9 @@ type ServiceClientPool struct {
 
 	// Round-robin counters per service
 	counters map[string]*atomic.Uint64
+
+	// Prefer the least-loaded connected provider over round-robin
+	leastLoaded atomic.Bool
 }
 
 // NewServiceClientPool creates a connection pool for service clients.
@@ -59,7 +62,11 @@ func (p *ServiceClientPool) getOrCreateConn(ctx context.Context, address string)
 	}
 
 	creds := credentials.NewTLS(p.node.TLSConfig.GetClientTLSConfig(address))
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	opts = append(opts, interceptorDialOptions(p.node.tracerProvider)...)
+	opts = append(opts, messageSizeDialOptions(p.node.maxMessageSize)...)
+
+	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,25 +97,68 @@ func (p *ServiceClientPool) getCounter(serviceKey string) *atomic.Uint64 {
 	return counter
 }
 
+// SetLeastLoaded makes GetConn prefer the healthy provider reporting the lowest
+// load among those connected as peers, falling back to round-robin when none
+// can report.
+func (p *ServiceClientPool) SetLeastLoaded(enabled bool) {
+	p.leastLoaded.Store(enabled)
+}
+
 // GetConn returns a connection to a provider of the specified service.
-// Uses round-robin to distribute calls across providers.
+// Uses round-robin to distribute calls across providers unless least-loaded
+// selection is enabled.
 func (p *ServiceClientPool) GetConn(ctx context.Context, name, version string) (*grpc.ClientConn, error) {
+	provider, err := p.selectProvider(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	return p.getOrCreateConn(ctx, provider.Address)
+}
+
+// selectProvider picks the provider GetConn connects to.
+func (p *ServiceClientPool) selectProvider(ctx context.Context, name, version string) (NodeInfo, error) {
 	if p.node.Topology == nil {
-		return nil, ErrNoProviders
+		return NodeInfo{}, ErrNoProviders
 	}
 
 	providers := p.node.Topology.GetServiceProviders(name, version)
 	if len(providers) == 0 {
-		return nil, ErrNoProviders
+		return NodeInfo{}, ErrNoProviders
+	}
+
+	if p.leastLoaded.Load() {
+		if provider, ok := p.leastLoadedProvider(ctx, providers); ok {
+			return provider, nil
+		}
 	}
 
 	// Round-robin selection
 	serviceKey := name + "/" + version
 	counter := p.getCounter(serviceKey)
 	idx := counter.Add(1) - 1
-	provider := providers[idx%uint64(len(providers))]
+	return providers[idx%uint64(len(providers))], nil
+}
 
-	return p.getOrCreateConn(ctx, provider.Address)
+// leastLoadedProvider returns the provider whose peer reports the lowest load.
+// Providers that are not connected peers cannot report load and are skipped.
+func (p *ServiceClientPool) leastLoadedProvider(ctx context.Context, providers []NodeInfo) (NodeInfo, bool) {
+	var peers []*Peer
+	byID := make(map[string]NodeInfo, len(providers))
+	for _, provider := range providers {
+		if peer, ok := p.node.GetPeer(provider.ID); ok {
+			peers = append(peers, peer)
+			byID[provider.ID] = provider
+		}
+	}
+	if len(peers) == 0 {
+		return NodeInfo{}, false
+	}
+
+	peer, err := p.node.leastLoaded(ctx, peers)
+	if err != nil {
+		return NodeInfo{}, false
+	}
+	return byID[peer.Info.ID], true
 }
 
 // Close closes all connections in the pool.
@@ -84,6 +84,51 @@ func TestServiceClientPoolRoundRobin(t *testing.T) {
 	}
 }
 
+func TestServiceClientPoolLeastLoaded(t *testing.T) {
+	node := NewNode("test", "Test", NodeTypeGeneric, "localhost:8443")
+	busy := NewNode("busy", "Busy", NodeTypeGeneric, "localhost:9001")
+	idle := NewNode("idle", "Idle", NodeTypeGeneric, "localhost:9002")
+
+	for _, provider := range []*Node{busy, idle} {
+		_ = node.Topology.AddNode(NodeInfo{
+			ID:       provider.ID,
+			Name:     provider.Name,
+			Type:     NodeTypeGeneric,
+			Address:  provider.Address,
+			Services: []ServiceInfo{{Name: "identity", Version: "v1"}},
+		})
+		connectLoopback(node, provider)
+		provider.Health.Update(HealthStatusHealthy, "ok", nil)
+	}
+
+	scores := map[string]float64{"busy": 10, "idle": 1}
+	node.SetLoadScorer(func(load Load) float64 { return scores[load.NodeID] })
+
+	pool := NewServiceClientPool(node)
+	defer pool.Close()
+	pool.SetLeastLoaded(true)
+
+	for range 3 {
+		provider, err := pool.selectProvider(context.Background(), "identity", "v1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if provider.ID != "idle" {
+			t.Errorf("expected idle provider, got %s", provider.ID)
+		}
+	}
+
+	// With no provider able to report load, selection falls back to round-robin.
+	idle.Health.Update(HealthStatusUnhealthy, "failing", nil)
+	busy.Health.Update(HealthStatusUnhealthy, "failing", nil)
+	if _, err := pool.selectProvider(context.Background(), "identity", "v1"); err != nil {
+		t.Errorf("expected round-robin fallback, got %v", err)
+	}
+	if counter := pool.getCounter("identity/v1"); counter.Load() != 1 {
+		t.Errorf("expected round-robin counter to advance once, got %d", counter.Load())
+	}
+}
+
 // mockClient is a fake gRPC client for testing
 type mockClient struct{}
 
@@ -22,6 +22,7 @@ var (
 type Caller struct {
 	NodeID      string
 	Certificate *x509.Certificate
+	TLS         TLSConnectionInfo
 }
 
 // CallerFromContext extracts the caller's identity from the gRPC context.
@@ -46,9 +47,18 @@ func CallerFromContext(ctx context.Context) (*Caller, error) {
 	return &Caller{
 		NodeID:      cert.Subject.CommonName,
 		Certificate: cert,
+		TLS:         tlsConnectionInfo(tlsInfo),
 	}, nil
 }
 
+// tlsConnectionInfo extracts the negotiated TLS parameters from gRPC auth info.
+func tlsConnectionInfo(info credentials.TLSInfo) TLSConnectionInfo {
+	return TLSConnectionInfo{
+		Version:     info.State.Version,
+		CipherSuite: info.State.CipherSuite,
+	}
+}
+
 // MustCallerFromContext extracts the caller's identity, panicking on error.
 // Use only when mTLS is guaranteed (e.g., after middleware validation).
 func MustCallerFromContext(ctx context.Context) *Caller {
@@ -0,0 +1,149 @@
+package aegis
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Discoverer finds candidate peers to bootstrap from.
+// Discoverers only return candidates; connecting to them is left to the caller.
+type Discoverer interface {
+	Discover(ctx context.Context) ([]PeerInfo, error)
+}
+
+// StaticDiscoverer returns a fixed list of seed addresses.
+type StaticDiscoverer struct {
+	addresses []string
+	nodeType  NodeType
+}
+
+// NewStaticDiscoverer creates a discoverer for a fixed set of seed addresses.
+func NewStaticDiscoverer(addresses ...string) *StaticDiscoverer {
+	return &StaticDiscoverer{
+		addresses: addresses,
+		nodeType:  NodeTypeGeneric,
+	}
+}
+
+// Discover returns a candidate for each seed address.
+// The host portion of each address is used as a placeholder peer ID;
+// use Node.ResolvePeer to learn the node ID before adding the peer.
+func (s *StaticDiscoverer) Discover(ctx context.Context) ([]PeerInfo, error) {
+	peers := make([]PeerInfo, 0, len(s.addresses))
+	for _, addr := range s.addresses {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seed address %s: %w", addr, err)
+		}
+		peers = append(peers, PeerInfo{
+			ID:      host,
+			Address: addr,
+			Type:    s.nodeType,
+		})
+	}
+	return peers, nil
+}
+
+// DNSDiscoverer finds candidates through DNS SRV records,
+// e.g. _aegis._tcp.mesh.example.com or a Kubernetes headless service.
+type DNSDiscoverer struct {
+	Service  string
+	Proto    string
+	Name     string
+	Type     NodeType
+	Resolver *net.Resolver
+}
+
+// NewDNSDiscoverer creates a discoverer for the SRV record _service._proto.name.
+func NewDNSDiscoverer(service, proto, name string) *DNSDiscoverer {
+	return &DNSDiscoverer{
+		Service:  service,
+		Proto:    proto,
+		Name:     name,
+		Type:     NodeTypeGeneric,
+		Resolver: net.DefaultResolver,
+	}
+}
+
+// Discover looks up the SRV record and returns a candidate per target.
+// The target host name is used as a placeholder peer ID;
+// use Node.ResolvePeer to learn the node ID before adding the peer.
+func (d *DNSDiscoverer) Discover(ctx context.Context) ([]PeerInfo, error) {
+	resolver := d.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	_, records, err := resolver.LookupSRV(ctx, d.Service, d.Proto, d.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up SRV records for %s: %w", d.Name, err)
+	}
+
+	peers := make([]PeerInfo, 0, len(records))
+	for _, srv := range records {
+		host := strings.TrimSuffix(srv.Target, ".")
+		peers = append(peers, PeerInfo{
+			ID:      host,
+			Address: net.JoinHostPort(host, strconv.Itoa(int(srv.Port))),
+			Type:    d.Type,
+		})
+	}
+	return peers, nil
+}
+
+// DiscoverPeers returns candidate peers found by the discoverer.
+// Candidates matching this node's own address and duplicate addresses are dropped.
+// No connections are made.
+func (n *Node) DiscoverPeers(ctx context.Context, discoverer Discoverer) ([]PeerInfo, error) {
+	if discoverer == nil {
+		return nil, fmt.Errorf("discoverer is nil")
+	}
+
+	candidates, err := discoverer.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(candidates))
+	peers := make([]PeerInfo, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Address == n.Address || seen[candidate.Address] {
+			continue
+		}
+		seen[candidate.Address] = true
+		peers = append(peers, candidate)
+	}
+	return peers, nil
+}
+
+// ResolvePeer connects to a discovered candidate and returns it with the node ID
+// taken from its certificate. Peer connections verify the certificate against
+// the peer ID, so candidates must be resolved before they are passed to AddPeer.
+func (n *Node) ResolvePeer(ctx context.Context, candidate PeerInfo) (PeerInfo, error) {
+	if n.TLSConfig == nil {
+		return PeerInfo{}, fmt.Errorf("TLS not configured")
+	}
+
+	dialer := &tls.Dialer{Config: n.TLSConfig.GetIdentifyTLSConfig()}
+	conn, err := dialer.DialContext(ctx, "tcp", candidate.Address)
+	if err != nil {
+		return PeerInfo{}, fmt.Errorf("failed to connect to %s: %w", candidate.Address, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return PeerInfo{}, fmt.Errorf("unexpected connection type %T", conn)
+	}
+	id := tlsConn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	if id == "" {
+		return PeerInfo{}, fmt.Errorf("certificate from %s has no node ID", candidate.Address)
+	}
+
+	candidate.ID = id
+	return candidate, nil
+}
@@ -0,0 +1,117 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestStaticDiscoverer(t *testing.T) {
+	d := NewStaticDiscoverer("10.0.0.1:8443", "seed.example.com:8443")
+
+	peers, err := d.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(peers) != 2 {
+		t.Fatalf("expected 2 peers, got %d", len(peers))
+	}
+	if peers[1].ID != "seed.example.com" {
+		t.Errorf("expected ID 'seed.example.com', got '%s'", peers[1].ID)
+	}
+	if peers[1].Address != "seed.example.com:8443" {
+		t.Errorf("expected address 'seed.example.com:8443', got '%s'", peers[1].Address)
+	}
+}
+
+func TestStaticDiscovererInvalidAddress(t *testing.T) {
+	d := NewStaticDiscoverer("not-a-valid-address")
+
+	_, err := d.Discover(context.Background())
+	if err == nil {
+		t.Error("expected error for invalid seed address")
+	}
+}
+
+func TestDNSDiscovererLookupFailure(t *testing.T) {
+	d := NewDNSDiscoverer("aegis", "tcp", "mesh.example.com")
+	d.Resolver = &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, errors.New("dns unavailable")
+		},
+	}
+
+	_, err := d.Discover(context.Background())
+	if err == nil {
+		t.Error("expected error when SRV lookup fails")
+	}
+}
+
+func TestNodeDiscoverPeersFiltersSelfAndDuplicates(t *testing.T) {
+	node := NewNode("node-1", "Node 1", NodeTypeGeneric, "10.0.0.1:8443")
+	d := NewStaticDiscoverer("10.0.0.1:8443", "10.0.0.2:8443", "10.0.0.2:8443", "10.0.0.3:8443")
+
+	peers, err := node.DiscoverPeers(context.Background(), d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(peers) != 2 {
+		t.Fatalf("expected 2 candidates, got %d", len(peers))
+	}
+	if node.PeerManager.Count() != 0 {
+		t.Error("discovery should not connect to candidates")
+	}
+}
+
+func TestNodeDiscoverPeersNilDiscoverer(t *testing.T) {
+	node := NewNode("node-1", "Node 1", NodeTypeGeneric, "10.0.0.1:8443")
+
+	_, err := node.DiscoverPeers(context.Background(), nil)
+	if err == nil {
+		t.Error("expected error for nil discoverer")
+	}
+}
+
+func TestNodeResolvePeerJoinsOverTLS(t *testing.T) {
+	certDir := t.TempDir()
+	seed := startTLSNode(t, certDir, "seed-node")
+	joiner := startTLSNode(t, certDir, "joiner")
+
+	candidates, err := joiner.DiscoverPeers(context.Background(), NewStaticDiscoverer(seed.Address))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if candidates[0].ID != "127.0.0.1" {
+		t.Fatalf("expected placeholder ID '127.0.0.1', got '%s'", candidates[0].ID)
+	}
+
+	info, err := joiner.ResolvePeer(context.Background(), candidates[0])
+	if err != nil {
+		t.Fatalf("failed to resolve peer: %v", err)
+	}
+	if info.ID != "seed-node" {
+		t.Fatalf("expected ID 'seed-node', got '%s'", info.ID)
+	}
+
+	results := joiner.AddPeers(context.Background(), []PeerInfo{info})
+	if err := results["seed-node"]; err != nil {
+		t.Fatalf("failed to add resolved peer: %v", err)
+	}
+	if _, err := joiner.PingPeer(context.Background(), "seed-node"); err != nil {
+		t.Errorf("failed to ping resolved peer over mTLS: %v", err)
+	}
+}
+
+func TestNodeResolvePeerRejectsUnknownCA(t *testing.T) {
+	seed := startTLSNode(t, t.TempDir(), "seed-node")
+	joiner := startTLSNode(t, t.TempDir(), "joiner")
+
+	_, err := joiner.ResolvePeer(context.Background(), PeerInfo{ID: "127.0.0.1", Address: seed.Address})
+	if err == nil {
+		t.Error("expected error for peer signed by another CA, got nil")
+	}
+}
@@ -0,0 +1,149 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrDraining is returned (as gRPC Unavailable) for calls received while the server is draining.
+var ErrDraining = errors.New("server is draining")
+
+// drainState counts in-flight calls and rejects new ones once draining starts.
+type drainState struct {
+	mu       sync.Mutex
+	draining bool
+	inflight int
+	idle     chan struct{}
+}
+
+// begin registers a new call. Returns false if the server is draining.
+func (d *drainState) begin() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return false
+	}
+	d.inflight++
+	return true
+}
+
+// end marks a call as finished.
+func (d *drainState) end() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inflight--
+	if d.draining && d.inflight == 0 {
+		close(d.idle)
+	}
+}
+
+// drain starts draining and returns a channel closed once no calls are in flight.
+// The second return value is false if draining had already started.
+func (d *drainState) drain() (<-chan struct{}, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return d.idle, false
+	}
+
+	d.draining = true
+	d.idle = make(chan struct{})
+	if d.inflight == 0 {
+		close(d.idle)
+	}
+	return d.idle, true
+}
+
+// reset stops draining so a restarted server accepts calls again.
+func (d *drainState) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.draining = false
+	d.idle = nil
+}
+
+// inFlight returns the number of calls currently being handled.
+func (d *drainState) inFlight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.inflight
+}
+
+// isDraining reports whether draining has started.
+func (d *drainState) isDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// unaryInterceptor rejects unary calls while draining and tracks in-flight ones.
+// GetHealth is always served so callers can see that the node is draining.
+func (d *drainState) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if info.FullMethod == MeshService_GetHealth_FullMethodName {
+		return handler(ctx, req)
+	}
+	if !d.begin() {
+		return nil, status.Error(codes.Unavailable, ErrDraining.Error())
+	}
+	defer d.end()
+	return handler(ctx, req)
+}
+
+// streamInterceptor rejects streams while draining and tracks in-flight ones.
+func (d *drainState) streamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !d.begin() {
+		return status.Error(codes.Unavailable, ErrDraining.Error())
+	}
+	defer d.end()
+	return handler(srv, ss)
+}
+
+// Drain stops accepting new RPCs, rejecting them with Unavailable so clients
+// retry elsewhere, and waits for in-flight RPCs to finish or ctx to expire.
+// GetHealth keeps being served and reports draining, so peers polling health
+// learn the node is leaving. The server keeps listening until Stop is called.
+func (ms *MeshServer) Drain(ctx context.Context) error {
+	idle, started := ms.drain.drain()
+	if started && ms.node != nil {
+		ms.node.notifyDraining()
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsDraining reports whether the server has started draining.
+func (ms *MeshServer) IsDraining() bool {
+	return ms.drain.isDraining()
+}
+
+// OnDraining sets the handler invoked when the node's server starts draining,
+// e.g. to deregister from a load balancer or announce the node is leaving.
+func (n *Node) OnDraining(handler func()) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.drainingHandler = handler
+}
+
+// notifyDraining invokes the draining handler, if set.
+func (n *Node) notifyDraining() {
+	n.mu.RLock()
+	handler := n.drainingHandler
+	n.mu.RUnlock()
+
+	if handler != nil {
+		handler()
+	}
+}
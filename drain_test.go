@@ -0,0 +1,94 @@
+package aegis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDrainRejectsNewCalls(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	ms := node.MeshServer
+
+	drained := false
+	node.OnDraining(func() { drained = true })
+
+	if err := ms.Drain(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !drained {
+		t.Error("expected draining handler to be called")
+	}
+	if !ms.IsDraining() {
+		t.Error("expected server to report draining")
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		t.Error("handler should not run while draining")
+		return nil, nil
+	}
+	_, err := ms.drain.unaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+}
+
+func TestDrainWaitsForInFlightCalls(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	ms := node.MeshServer
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := func(ctx context.Context, req any) (any, error) {
+		close(started)
+		<-release
+		return "done", nil
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := ms.drain.unaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+		result <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := ms.Drain(ctx); err == nil {
+		t.Fatal("expected drain to time out while a call is in flight")
+	}
+
+	close(release)
+	if err := <-result; err != nil {
+		t.Errorf("expected in-flight call to complete, got %v", err)
+	}
+
+	if err := ms.Drain(context.Background()); err != nil {
+		t.Errorf("expected drain to complete once idle, got %v", err)
+	}
+}
+
+func TestDrainKeepsServingHealth(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	ms := node.MeshServer
+
+	if err := ms.Drain(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return ms.GetHealth(ctx, req.(*HealthRequest))
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: MeshService_GetHealth_FullMethodName}
+	resp, err := ms.drain.unaryInterceptor(context.Background(), &HealthRequest{SenderId: "peer"}, info, handler)
+	if err != nil {
+		t.Fatalf("expected GetHealth to be served while draining, got %v", err)
+	}
+	if !resp.(*HealthResponse).Draining {
+		t.Error("expected health response to report draining")
+	}
+}
@@ -5,6 +5,8 @@ go 1.24
 toolchain go1.25.5
 
 require (
+	go.opentelemetry.io/otel v1.37.0
+	go.opentelemetry.io/otel/trace v1.37.0
 	google.golang.org/grpc v1.74.2
 	google.golang.org/protobuf v1.36.6
 )
@@ -16,12 +16,24 @@ const (
 	HealthStatusUnknown   HealthStatus = "unknown"
 )
 
+// DefaultHealthHistorySize is the number of status transitions retained by HealthInfo.
+const DefaultHealthHistorySize = 16
+
+// HealthTransition records a change in health status.
+type HealthTransition struct {
+	Status    HealthStatus `json:"status"`
+	Message   string       `json:"message,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
 // HealthInfo contains health status information for a node.
 type HealthInfo struct {
 	Status      HealthStatus `json:"status"`
 	LastChecked time.Time    `json:"last_checked"`
 	Message     string       `json:"message,omitempty"`
 	Error       string       `json:"error,omitempty"`
+	history     []HealthTransition
+	transitions uint64
 	mu          sync.RWMutex `json:"-"`
 }
 
@@ -38,6 +50,14 @@ func (h *HealthInfo) Update(status HealthStatus, message string, err error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	
+	if status != h.Status {
+		h.recordTransition(HealthTransition{
+			Status:    status,
+			Message:   message,
+			Timestamp: time.Now(),
+		})
+	}
+
 	h.Status = status
 	h.LastChecked = time.Now()
 	h.Message = message
@@ -56,6 +76,36 @@ func (h *HealthInfo) Get() (HealthStatus, time.Time, string, string) {
 	return h.Status, h.LastChecked, h.Message, h.Error
 }
 
+// History returns recent status transitions, oldest first.
+// Only changes in status are recorded, not every check.
+func (h *HealthInfo) History() []HealthTransition {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	history := make([]HealthTransition, len(h.history))
+	copy(history, h.history)
+	return history
+}
+
+// Transitions returns the total number of status transitions since creation.
+// Unlike History it is not bounded.
+func (h *HealthInfo) Transitions() uint64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.transitions
+}
+
+// recordTransition appends a transition, discarding the oldest when full.
+// Callers must hold the write lock.
+func (h *HealthInfo) recordTransition(t HealthTransition) {
+	h.transitions++
+	if len(h.history) == DefaultHealthHistorySize {
+		copy(h.history, h.history[1:])
+		h.history = h.history[:DefaultHealthHistorySize-1]
+	}
+	h.history = append(h.history, t)
+}
+
 func (h *HealthInfo) IsHealthy() bool {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -195,4 +195,71 @@ func TestNodeCheckHealthNilChecker(t *testing.T) {
 	if node.IsHealthy() {
 		t.Error("node should not be healthy after nil checker")
 	}
-}
\ No newline at end of file
+}
+
+func TestHealthInfoHistoryRecordsChangesOnly(t *testing.T) {
+	health := NewHealthInfo()
+
+	health.Update(HealthStatusHealthy, "up", nil)
+	health.Update(HealthStatusHealthy, "still up", nil)
+	health.Update(HealthStatusUnhealthy, "down", fmt.Errorf("boom"))
+	health.Update(HealthStatusHealthy, "recovered", nil)
+
+	history := health.History()
+	if len(history) != 3 {
+		t.Fatalf("expected 3 transitions, got %d", len(history))
+	}
+
+	expected := []HealthStatus{HealthStatusHealthy, HealthStatusUnhealthy, HealthStatusHealthy}
+	for i, status := range expected {
+		if history[i].Status != status {
+			t.Errorf("transition %d: expected %s, got %s", i, status, history[i].Status)
+		}
+	}
+	if history[2].Message != "recovered" {
+		t.Errorf("expected message 'recovered', got '%s'", history[2].Message)
+	}
+}
+
+func TestHealthInfoHistoryBounded(t *testing.T) {
+	health := NewHealthInfo()
+
+	for i := 0; i < DefaultHealthHistorySize*2; i++ {
+		if i%2 == 0 {
+			health.Update(HealthStatusHealthy, fmt.Sprintf("check %d", i), nil)
+		} else {
+			health.Update(HealthStatusUnhealthy, fmt.Sprintf("check %d", i), nil)
+		}
+	}
+
+	history := health.History()
+	if len(history) != DefaultHealthHistorySize {
+		t.Fatalf("expected %d transitions, got %d", DefaultHealthHistorySize, len(history))
+	}
+
+	last := fmt.Sprintf("check %d", DefaultHealthHistorySize*2-1)
+	if history[len(history)-1].Message != last {
+		t.Errorf("expected newest transition '%s', got '%s'", last, history[len(history)-1].Message)
+	}
+	if health.Transitions() != DefaultHealthHistorySize*2 {
+		t.Errorf("expected %d total transitions, got %d", DefaultHealthHistorySize*2, health.Transitions())
+	}
+}
+
+func TestGetHealthIncludesHistory(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.SetHealth(HealthStatusHealthy, "up", nil)
+	node.SetHealth(HealthStatusUnhealthy, "down", nil)
+
+	resp, err := node.MeshServer.GetHealth(context.Background(), &HealthRequest{SenderId: "peer"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.History) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(resp.History))
+	}
+	if resp.History[1].Status != string(HealthStatusUnhealthy) {
+		t.Errorf("expected latest status %s, got %s", HealthStatusUnhealthy, resp.History[1].Status)
+	}
+}
@@ -0,0 +1,134 @@
+package aegis
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// loopbackClient routes mesh RPCs directly to another node's server.
+type loopbackClient struct {
+	MeshServiceClient
+	server *MeshServer
+}
+
+func (c *loopbackClient) Ping(ctx context.Context, req *PingRequest, _ ...grpc.CallOption) (*PingResponse, error) {
+	return c.server.Ping(ctx, req)
+}
+
+func (c *loopbackClient) GetLoad(ctx context.Context, req *LoadRequest, _ ...grpc.CallOption) (*LoadResponse, error) {
+	return c.server.GetLoad(ctx, req)
+}
+
+func (c *loopbackClient) SyncTopology(ctx context.Context, req *TopologySyncRequest, _ ...grpc.CallOption) (*TopologySyncResponse, error) {
+	return c.server.SyncTopology(ctx, req)
+}
+
+func (c *loopbackClient) SyncTopologyStream(ctx context.Context, req *TopologySyncRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[TopologySyncChunk], error) {
+	collector := &chunkCollector{}
+	if err := c.server.SyncTopologyStream(req, collector); err != nil {
+		return nil, err
+	}
+	return &chunkReplayer{chunks: collector.chunks}, nil
+}
+
+// chunkCollector records chunks sent by a SyncTopologyStream handler.
+type chunkCollector struct {
+	grpc.ServerStream
+	chunks []*TopologySyncChunk
+}
+
+func (s *chunkCollector) Send(chunk *TopologySyncChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+// chunkReplayer replays recorded chunks to a SyncTopologyStream client.
+type chunkReplayer struct {
+	grpc.ClientStream
+	chunks []*TopologySyncChunk
+}
+
+func (s *chunkReplayer) Recv() (*TopologySyncChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (c *loopbackClient) Broadcast(ctx context.Context, req *BroadcastRequest, _ ...grpc.CallOption) (*BroadcastResponse, error) {
+	return c.server.Broadcast(ctx, req)
+}
+
+// connectLoopback links two nodes as peers of each other without a network.
+func connectLoopback(a, b *Node) {
+	a.PeerManager.peers[b.ID] = &Peer{
+		Info:   PeerInfo{ID: b.ID, Address: b.Address, Type: b.Type},
+		Client: &loopbackClient{server: b.MeshServer},
+	}
+	b.PeerManager.peers[a.ID] = &Peer{
+		Info:   PeerInfo{ID: a.ID, Address: a.Address, Type: a.Type},
+		Client: &loopbackClient{server: a.MeshServer},
+	}
+}
+
+// blockingClient blocks every call until its context is done, counting calls.
+type blockingClient struct {
+	MeshServiceClient
+	calls *atomic.Int32
+}
+
+func (c *blockingClient) SyncTopology(ctx context.Context, _ *TopologySyncRequest, _ ...grpc.CallOption) (*TopologySyncResponse, error) {
+	c.calls.Add(1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (c *blockingClient) GetLoad(ctx context.Context, _ *LoadRequest, _ ...grpc.CallOption) (*LoadResponse, error) {
+	c.calls.Add(1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (c *blockingClient) Broadcast(ctx context.Context, _ *BroadcastRequest, _ ...grpc.CallOption) (*BroadcastResponse, error) {
+	c.calls.Add(1)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// addBlockingPeers adds count peers that block until the call is cancelled.
+func addBlockingPeers(node *Node, count int) *atomic.Int32 {
+	calls := &atomic.Int32{}
+	for i := range count {
+		id := fmt.Sprintf("peer-%d", i)
+		node.PeerManager.peers[id] = &Peer{
+			Info:   PeerInfo{ID: id},
+			Client: &blockingClient{calls: calls},
+		}
+	}
+	return calls
+}
+
+// startTLSNode starts a node serving the mesh over mTLS on a free loopback port.
+// Certificates are generated in certDir, so nodes sharing it trust each other.
+func startTLSNode(t *testing.T, certDir, id string) *Node {
+	t.Helper()
+
+	node := NewNode(id, id, NodeTypeGeneric, "127.0.0.1:0")
+	if err := node.EnableTLS(certDir); err != nil {
+		t.Fatalf("failed to enable TLS: %v", err)
+	}
+	if err := node.StartServer(); err != nil {
+		t.Fatalf("failed to start server: %v", err)
+	}
+	node.Address = node.MeshServer.listener.Addr().String()
+	t.Cleanup(func() { _ = node.Shutdown() })
+
+	return node
+}
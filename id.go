@@ -0,0 +1,32 @@
+package aegis
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// NewID returns a random 128-bit identifier encoded as 32 hex characters.
+// Use it for message, request and correlation IDs so they are unpredictable
+// and unique across nodes.
+func NewID() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read never returns an error and always fills b.
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// serialNumberLimit bounds certificate serial numbers to 128 bits, within the
+// 20-octet maximum allowed by RFC 5280.
+var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// newSerialNumber returns a random positive certificate serial number.
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	// Serial numbers must be positive.
+	return serial.Add(serial, big.NewInt(1)), nil
+}
@@ -0,0 +1,39 @@
+package aegis
+
+import (
+	"testing"
+)
+
+func TestNewID(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 1000 {
+		id := NewID()
+		if len(id) != 32 {
+			t.Fatalf("expected 32 character ID, got %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate ID generated: %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestNewSerialNumber(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		serial, err := newSerialNumber()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if serial.Sign() <= 0 {
+			t.Fatalf("expected positive serial, got %s", serial)
+		}
+		if serial.BitLen() > 129 {
+			t.Fatalf("serial too large: %d bits", serial.BitLen())
+		}
+		if seen[serial.String()] {
+			t.Fatalf("duplicate serial generated: %s", serial)
+		}
+		seen[serial.String()] = true
+	}
+}
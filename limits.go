@@ -0,0 +1,86 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultMaxMessageSize is the default limit, in bytes, for messages sent or received over the mesh.
+const DefaultMaxMessageSize = 4 << 20
+
+// ErrMessageTooLarge is returned when an outgoing request exceeds the configured maximum message size.
+var ErrMessageTooLarge = errors.New("message exceeds maximum size")
+
+// messageSizeLimit returns the configured limit or the default when unset.
+func messageSizeLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultMaxMessageSize
+	}
+	return limit
+}
+
+// messageSizeServerOptions limits the size of messages the server receives and sends.
+func messageSizeServerOptions(limit int) []grpc.ServerOption {
+	limit = messageSizeLimit(limit)
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(limit),
+		grpc.MaxSendMsgSize(limit),
+	}
+}
+
+// messageSizeDialOptions limits the size of messages a client connection receives and sends.
+// Oversized unary requests fail locally with ErrMessageTooLarge instead of a transport error.
+func messageSizeDialOptions(limit int) []grpc.DialOption {
+	limit = messageSizeLimit(limit)
+	return []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(limit),
+			grpc.MaxCallSendMsgSize(limit),
+		),
+		grpc.WithChainUnaryInterceptor(messageSizeUnaryClientInterceptor(limit)),
+	}
+}
+
+// messageSizeUnaryClientInterceptor rejects oversized requests before they are sent.
+func messageSizeUnaryClientInterceptor(limit int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if msg, ok := req.(proto.Message); ok {
+			if size := proto.Size(msg); size > limit {
+				return fmt.Errorf("%w: %s request is %d bytes, limit is %d", ErrMessageTooLarge, method, size, limit)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// SetMaxMessageSize sets the maximum message size, in bytes, for the server.
+// Must be called before Start.
+func (ms *MeshServer) SetMaxMessageSize(limit int) {
+	ms.maxMessageSize = limit
+}
+
+// SetMaxMessageSize sets the maximum message size, in bytes, for peer connections.
+// Applies to peers added after the call.
+func (pm *PeerManager) SetMaxMessageSize(limit int) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.maxMessageSize = limit
+}
+
+// SetMaxMessageSize sets the maximum message size, in bytes, for the node's server,
+// peer connections and service client connections. Zero selects DefaultMaxMessageSize.
+func (n *Node) SetMaxMessageSize(limit int) {
+	n.maxMessageSize = limit
+
+	if n.MeshServer != nil {
+		n.MeshServer.SetMaxMessageSize(limit)
+	}
+
+	if n.PeerManager != nil {
+		n.PeerManager.SetMaxMessageSize(limit)
+	}
+}
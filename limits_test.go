@@ -0,0 +1,58 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestMessageSizeInterceptorRejectsOversizedRequest(t *testing.T) {
+	interceptor := messageSizeUnaryClientInterceptor(64)
+
+	called := false
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		called = true
+		return nil
+	}
+
+	req := &BroadcastRequest{Payload: make([]byte, 128)}
+	err := interceptor(context.Background(), "/aegis.MeshService/Broadcast", req, nil, nil, invoker)
+	if !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("expected ErrMessageTooLarge, got %v", err)
+	}
+	if called {
+		t.Error("expected oversized request not to be sent")
+	}
+
+	req = &BroadcastRequest{Payload: make([]byte, 16)}
+	if err := interceptor(context.Background(), "/aegis.MeshService/Broadcast", req, nil, nil, invoker); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected request within limit to be sent")
+	}
+}
+
+func TestMessageSizeLimitDefault(t *testing.T) {
+	if got := messageSizeLimit(0); got != DefaultMaxMessageSize {
+		t.Errorf("expected default limit %d, got %d", DefaultMaxMessageSize, got)
+	}
+	if got := messageSizeLimit(1024); got != 1024 {
+		t.Errorf("expected limit 1024, got %d", got)
+	}
+}
+
+func TestNodeSetMaxMessageSize(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	node.SetMaxMessageSize(1 << 20)
+
+	if node.MeshServer.maxMessageSize != 1<<20 {
+		t.Errorf("expected server limit to be set, got %d", node.MeshServer.maxMessageSize)
+	}
+	if node.PeerManager.maxMessageSize != 1<<20 {
+		t.Errorf("expected peer manager limit to be set, got %d", node.PeerManager.maxMessageSize)
+	}
+}
@@ -0,0 +1,181 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+const (
+	// heapObjectsMetric is the runtime metric for bytes occupied by live and unswept heap objects.
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+	// loadQueryTimeout bounds each peer's load query so one slow peer cannot stall selection.
+	loadQueryTimeout = 2 * time.Second
+)
+
+// ErrNoPeersAvailable is returned when no healthy peer could report its load.
+var ErrNoPeersAvailable = errors.New("no healthy peers available")
+
+// Load is a snapshot of a node's resource usage.
+type Load struct {
+	NodeID     string       `json:"node_id"`
+	InFlight   int          `json:"in_flight"`
+	Goroutines int          `json:"goroutines"`
+	HeapBytes  uint64       `json:"heap_bytes"`
+	NumCPU     int          `json:"num_cpu"`
+	Status     HealthStatus `json:"status"`
+}
+
+// LoadScorer ranks a load snapshot; lower scores are less loaded.
+type LoadScorer func(load Load) float64
+
+// DefaultLoadScorer scores a node by in-flight mesh requests per CPU.
+func DefaultLoadScorer(load Load) float64 {
+	return float64(load.InFlight) / float64(max(load.NumCPU, 1))
+}
+
+// CurrentLoad returns this node's current load.
+// Values are read from counters the runtime already maintains, so it is cheap
+// enough to compute on every request.
+func (n *Node) CurrentLoad() Load {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+
+	var heap uint64
+	if sample[0].Value.Kind() == metrics.KindUint64 {
+		heap = sample[0].Value.Uint64()
+	}
+
+	load := Load{
+		NodeID:     n.ID,
+		Goroutines: runtime.NumGoroutine(),
+		HeapBytes:  heap,
+		NumCPU:     runtime.NumCPU(),
+		Status:     HealthStatusUnknown,
+	}
+
+	if n.MeshServer != nil {
+		load.InFlight = n.MeshServer.drain.inFlight()
+	}
+
+	if n.Health != nil {
+		load.Status, _, _, _ = n.Health.Get()
+	}
+
+	return load
+}
+
+// SetLoadScorer sets the scoring function used by LeastLoadedPeer.
+// A nil scorer restores DefaultLoadScorer.
+func (n *Node) SetLoadScorer(scorer LoadScorer) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.loadScorer = scorer
+}
+
+// GetPeerLoad retrieves the current load of a peer.
+func (pm *PeerManager) GetPeerLoad(ctx context.Context, peerID string) (*LoadResponse, error) {
+	peer, exists := pm.GetPeer(peerID)
+	if !exists {
+		return nil, fmt.Errorf("peer %s not found", peerID)
+	}
+
+	req := &LoadRequest{
+		SenderId: pm.nodeID,
+	}
+
+	return peer.Client.GetLoad(ctx, req)
+}
+
+// GetPeerLoad retrieves the current load of a peer.
+func (n *Node) GetPeerLoad(ctx context.Context, peerID string) (*LoadResponse, error) {
+	if n.PeerManager == nil {
+		return nil, fmt.Errorf("peer manager not initialized")
+	}
+	return n.PeerManager.GetPeerLoad(ctx, peerID)
+}
+
+// LeastLoadedPeer queries peers of the given type concurrently and returns the
+// healthy peer with the lowest load score. Peers that fail to respond within
+// loadQueryTimeout are skipped.
+func (n *Node) LeastLoadedPeer(ctx context.Context, nodeType NodeType) (*Peer, error) {
+	if n.PeerManager == nil {
+		return nil, fmt.Errorf("peer manager not initialized")
+	}
+	return n.leastLoaded(ctx, n.PeerManager.GetPeersByType(nodeType))
+}
+
+// leastLoaded queries the given peers concurrently, each under its own timeout,
+// and returns the healthy peer with the lowest load score.
+func (n *Node) leastLoaded(ctx context.Context, peers []*Peer) (*Peer, error) {
+	n.mu.RLock()
+	scorer := n.loadScorer
+	n.mu.RUnlock()
+	if scorer == nil {
+		scorer = DefaultLoadScorer
+	}
+
+	loads := make([]*Load, len(peers))
+
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, peer *Peer) {
+			defer wg.Done()
+			queryCtx, cancel := context.WithTimeout(ctx, loadQueryTimeout)
+			defer cancel()
+			resp, err := n.PeerManager.GetPeerLoad(queryCtx, peer.Info.ID)
+			if err != nil {
+				return
+			}
+			load := protoToLoad(resp)
+			loads[i] = &load
+		}(i, peer)
+	}
+	wg.Wait()
+
+	var best *Peer
+	var bestScore float64
+	for i, load := range loads {
+		if load == nil || load.Status != HealthStatusHealthy {
+			continue
+		}
+		if score := scorer(*load); best == nil || score < bestScore {
+			best = peers[i]
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		return nil, ErrNoPeersAvailable
+	}
+	return best, nil
+}
+
+// loadToProto converts a Load to a LoadResponse proto message.
+func loadToProto(load Load) *LoadResponse {
+	return &LoadResponse{
+		NodeId:       load.NodeID,
+		InFlight:     int64(load.InFlight),
+		Goroutines:   int64(load.Goroutines),
+		HeapBytes:    load.HeapBytes,
+		NumCpu:       int64(load.NumCPU),
+		HealthStatus: string(load.Status),
+	}
+}
+
+// protoToLoad converts a LoadResponse proto message to a Load.
+func protoToLoad(resp *LoadResponse) Load {
+	return Load{
+		NodeID:     resp.NodeId,
+		InFlight:   int(resp.InFlight),
+		Goroutines: int(resp.Goroutines),
+		HeapBytes:  resp.HeapBytes,
+		NumCPU:     int(resp.NumCpu),
+		Status:     HealthStatus(resp.HealthStatus),
+	}
+}
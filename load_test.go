@@ -0,0 +1,88 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNodeCurrentLoad(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	load := node.CurrentLoad()
+	if load.NodeID != node.ID {
+		t.Errorf("expected node ID %s, got %s", node.ID, load.NodeID)
+	}
+	if load.Goroutines <= 0 || load.NumCPU <= 0 || load.HeapBytes == 0 {
+		t.Errorf("expected runtime metrics to be populated, got %+v", load)
+	}
+	if load.Status != HealthStatusUnknown {
+		t.Errorf("expected unknown status, got %s", load.Status)
+	}
+}
+
+func TestLeastLoadedPeer(t *testing.T) {
+	local := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	busy := NewNode("busy", "Busy", NodeTypeGeneric, "localhost:9002")
+	idle := NewNode("idle", "Idle", NodeTypeGeneric, "localhost:9003")
+	down := NewNode("down", "Down", NodeTypeGeneric, "localhost:9004")
+
+	for _, peer := range []*Node{busy, idle, down} {
+		connectLoopback(local, peer)
+	}
+	busy.Health.Update(HealthStatusHealthy, "ok", nil)
+	idle.Health.Update(HealthStatusHealthy, "ok", nil)
+	down.Health.Update(HealthStatusUnhealthy, "failing", nil)
+
+	scores := map[string]float64{"busy": 10, "idle": 1, "down": 0}
+	local.SetLoadScorer(func(load Load) float64 { return scores[load.NodeID] })
+
+	peer, err := local.LeastLoadedPeer(context.Background(), NodeTypeGeneric)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if peer.Info.ID != "idle" {
+		t.Errorf("expected idle peer, got %s", peer.Info.ID)
+	}
+}
+
+func TestLeastLoadedPeerSkipsUnresponsivePeer(t *testing.T) {
+	local := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	idle := NewNode("idle", "Idle", NodeTypeGeneric, "localhost:9002")
+	connectLoopback(local, idle)
+	idle.Health.Update(HealthStatusHealthy, "ok", nil)
+
+	calls := &atomic.Int32{}
+	local.PeerManager.peers["stuck"] = &Peer{
+		Info:   PeerInfo{ID: "stuck", Type: NodeTypeGeneric},
+		Client: &blockingClient{calls: calls},
+	}
+
+	done := make(chan *Peer, 1)
+	go func() {
+		peer, _ := local.LeastLoadedPeer(context.Background(), NodeTypeGeneric)
+		done <- peer
+	}()
+
+	select {
+	case peer := <-done:
+		if peer == nil || peer.Info.ID != "idle" {
+			t.Errorf("expected idle peer, got %v", peer)
+		}
+	case <-time.After(loadQueryTimeout + time.Second):
+		t.Fatal("expected the unresponsive peer's query to time out")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected one query to the unresponsive peer, got %d", calls.Load())
+	}
+}
+
+func TestLeastLoadedPeerNoneAvailable(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	if _, err := node.LeastLoadedPeer(context.Background(), NodeTypeGeneric); !errors.Is(err, ErrNoPeersAvailable) {
+		t.Errorf("expected ErrNoPeersAvailable, got %v", err)
+	}
+}
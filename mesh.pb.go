@@ -22,11 +22,12 @@ const (
 )
 
 type PingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SenderId        string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Timestamp       int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ProtocolVersion int32                  `protobuf:"varint,3,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PingRequest) Reset() {
@@ -73,13 +74,21 @@ func (x *PingRequest) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *PingRequest) GetProtocolVersion() int32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
 type PingResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReceiverId    string                 `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReceiverId      string                 `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	Timestamp       int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Success         bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ProtocolVersion int32                  `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PingResponse) Reset() {
@@ -133,6 +142,13 @@ func (x *PingResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *PingResponse) GetProtocolVersion() int32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
 type HealthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
@@ -178,12 +194,15 @@ func (x *HealthRequest) GetSenderId() string {
 }
 
 type HealthResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	LastChecked   int64                  `protobuf:"varint,3,opt,name=last_checked,json=lastChecked,proto3" json:"last_checked,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	state       protoimpl.MessageState    `protogen:"open.v1"`
+	NodeId      string                    `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Status      string                    `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	LastChecked int64                     `protobuf:"varint,3,opt,name=last_checked,json=lastChecked,proto3" json:"last_checked,omitempty"`
+	Message     string                    `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Error       string                    `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	History     []*HealthStatusTransition `protobuf:"bytes,6,rep,name=history,proto3" json:"history,omitempty"`
+	// Set while the node is draining and rejecting calls other than GetHealth.
+	Draining      bool `protobuf:"varint,7,opt,name=draining,proto3" json:"draining,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -253,6 +272,80 @@ func (x *HealthResponse) GetError() string {
 	return ""
 }
 
+func (x *HealthResponse) GetHistory() []*HealthStatusTransition {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+type HealthStatusTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthStatusTransition) Reset() {
+	*x = HealthStatusTransition{}
+	mi := &file_mesh_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthStatusTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthStatusTransition) ProtoMessage() {}
+
+func (x *HealthStatusTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthStatusTransition.ProtoReflect.Descriptor instead.
+func (*HealthStatusTransition) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HealthStatusTransition) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthStatusTransition) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HealthStatusTransition) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 type NodeInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
@@ -262,7 +355,7 @@ type NodeInfoRequest struct {
 
 func (x *NodeInfoRequest) Reset() {
 	*x = NodeInfoRequest{}
-	mi := &file_mesh_proto_msgTypes[4]
+	mi := &file_mesh_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -274,7 +367,7 @@ func (x *NodeInfoRequest) String() string {
 func (*NodeInfoRequest) ProtoMessage() {}
 
 func (x *NodeInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[4]
+	mi := &file_mesh_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -287,7 +380,7 @@ func (x *NodeInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeInfoRequest.ProtoReflect.Descriptor instead.
 func (*NodeInfoRequest) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{4}
+	return file_mesh_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NodeInfoRequest) GetSenderId() string {
@@ -310,7 +403,7 @@ type NodeInfoResponse struct {
 
 func (x *NodeInfoResponse) Reset() {
 	*x = NodeInfoResponse{}
-	mi := &file_mesh_proto_msgTypes[5]
+	mi := &file_mesh_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -322,7 +415,7 @@ func (x *NodeInfoResponse) String() string {
 func (*NodeInfoResponse) ProtoMessage() {}
 
 func (x *NodeInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[5]
+	mi := &file_mesh_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +428,7 @@ func (x *NodeInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeInfoResponse.ProtoReflect.Descriptor instead.
 func (*NodeInfoResponse) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{5}
+	return file_mesh_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *NodeInfoResponse) GetId() string {
@@ -378,13 +471,16 @@ type TopologySyncRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
 	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	AcceptDelta   bool                   `protobuf:"varint,3,opt,name=accept_delta,json=acceptDelta,proto3" json:"accept_delta,omitempty"`
+	Lineage       string                 `protobuf:"bytes,4,opt,name=lineage,proto3" json:"lineage,omitempty"`
+	AcceptStream  bool                   `protobuf:"varint,5,opt,name=accept_stream,json=acceptStream,proto3" json:"accept_stream,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TopologySyncRequest) Reset() {
 	*x = TopologySyncRequest{}
-	mi := &file_mesh_proto_msgTypes[6]
+	mi := &file_mesh_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -396,7 +492,7 @@ func (x *TopologySyncRequest) String() string {
 func (*TopologySyncRequest) ProtoMessage() {}
 
 func (x *TopologySyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[6]
+	mi := &file_mesh_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,7 +505,7 @@ func (x *TopologySyncRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopologySyncRequest.ProtoReflect.Descriptor instead.
 func (*TopologySyncRequest) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{6}
+	return file_mesh_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TopologySyncRequest) GetSenderId() string {
@@ -426,18 +522,47 @@ func (x *TopologySyncRequest) GetVersion() int64 {
 	return 0
 }
 
+func (x *TopologySyncRequest) GetAcceptDelta() bool {
+	if x != nil {
+		return x.AcceptDelta
+	}
+	return false
+}
+
+func (x *TopologySyncRequest) GetLineage() string {
+	if x != nil {
+		return x.Lineage
+	}
+	return ""
+}
+
+func (x *TopologySyncRequest) GetAcceptStream() bool {
+	if x != nil {
+		return x.AcceptStream
+	}
+	return false
+}
+
+// TopologySyncResponse carries either the full node list or, when delta is
+// set, only the changes since the requester's version. When stream_required is
+// set the topology is too large for a single message and the requester should
+// call SyncTopologyStream instead.
 type TopologySyncResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
-	Nodes         []*TopologyNode        `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Nodes          []*TopologyNode        `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Delta          bool                   `protobuf:"varint,4,opt,name=delta,proto3" json:"delta,omitempty"`
+	Changes        []*TopologyChange      `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	Lineage        string                 `protobuf:"bytes,6,opt,name=lineage,proto3" json:"lineage,omitempty"`
+	StreamRequired bool                   `protobuf:"varint,7,opt,name=stream_required,json=streamRequired,proto3" json:"stream_required,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TopologySyncResponse) Reset() {
 	*x = TopologySyncResponse{}
-	mi := &file_mesh_proto_msgTypes[7]
+	mi := &file_mesh_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +574,7 @@ func (x *TopologySyncResponse) String() string {
 func (*TopologySyncResponse) ProtoMessage() {}
 
 func (x *TopologySyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[7]
+	mi := &file_mesh_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +587,7 @@ func (x *TopologySyncResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopologySyncResponse.ProtoReflect.Descriptor instead.
 func (*TopologySyncResponse) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{7}
+	return file_mesh_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TopologySyncResponse) GetVersion() int64 {
@@ -486,6 +611,164 @@ func (x *TopologySyncResponse) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *TopologySyncResponse) GetDelta() bool {
+	if x != nil {
+		return x.Delta
+	}
+	return false
+}
+
+func (x *TopologySyncResponse) GetChanges() []*TopologyChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *TopologySyncResponse) GetLineage() string {
+	if x != nil {
+		return x.Lineage
+	}
+	return ""
+}
+
+func (x *TopologySyncResponse) GetStreamRequired() bool {
+	if x != nil {
+		return x.StreamRequired
+	}
+	return false
+}
+
+type TopologyChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Node          *TopologyNode          `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopologyChange) Reset() {
+	*x = TopologyChange{}
+	mi := &file_mesh_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopologyChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyChange) ProtoMessage() {}
+
+func (x *TopologyChange) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyChange.ProtoReflect.Descriptor instead.
+func (*TopologyChange) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TopologyChange) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TopologyChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TopologyChange) GetNode() *TopologyNode {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+// TopologySyncChunk carries one batch of a topology snapshot.
+// Every chunk of a stream has the same version.
+type TopologySyncChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Nodes         []*TopologyNode        `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Lineage       string                 `protobuf:"bytes,4,opt,name=lineage,proto3" json:"lineage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopologySyncChunk) Reset() {
+	*x = TopologySyncChunk{}
+	mi := &file_mesh_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopologySyncChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologySyncChunk) ProtoMessage() {}
+
+func (x *TopologySyncChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologySyncChunk.ProtoReflect.Descriptor instead.
+func (*TopologySyncChunk) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopologySyncChunk) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TopologySyncChunk) GetNodes() []*TopologyNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *TopologySyncChunk) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *TopologySyncChunk) GetLineage() string {
+	if x != nil {
+		return x.Lineage
+	}
+	return ""
+}
+
 type GetTopologyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
@@ -495,7 +778,7 @@ type GetTopologyRequest struct {
 
 func (x *GetTopologyRequest) Reset() {
 	*x = GetTopologyRequest{}
-	mi := &file_mesh_proto_msgTypes[8]
+	mi := &file_mesh_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -507,7 +790,7 @@ func (x *GetTopologyRequest) String() string {
 func (*GetTopologyRequest) ProtoMessage() {}
 
 func (x *GetTopologyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[8]
+	mi := &file_mesh_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +803,7 @@ func (x *GetTopologyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopologyRequest.ProtoReflect.Descriptor instead.
 func (*GetTopologyRequest) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{8}
+	return file_mesh_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetTopologyRequest) GetSenderId() string {
@@ -540,7 +823,7 @@ type GetTopologyResponse struct {
 
 func (x *GetTopologyResponse) Reset() {
 	*x = GetTopologyResponse{}
-	mi := &file_mesh_proto_msgTypes[9]
+	mi := &file_mesh_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -552,7 +835,7 @@ func (x *GetTopologyResponse) String() string {
 func (*GetTopologyResponse) ProtoMessage() {}
 
 func (x *GetTopologyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[9]
+	mi := &file_mesh_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +848,7 @@ func (x *GetTopologyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopologyResponse.ProtoReflect.Descriptor instead.
 func (*GetTopologyResponse) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{9}
+	return file_mesh_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetTopologyResponse) GetVersion() int64 {
@@ -597,7 +880,7 @@ type TopologyNode struct {
 
 func (x *TopologyNode) Reset() {
 	*x = TopologyNode{}
-	mi := &file_mesh_proto_msgTypes[10]
+	mi := &file_mesh_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -609,7 +892,7 @@ func (x *TopologyNode) String() string {
 func (*TopologyNode) ProtoMessage() {}
 
 func (x *TopologyNode) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[10]
+	mi := &file_mesh_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,7 +905,7 @@ func (x *TopologyNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopologyNode.ProtoReflect.Descriptor instead.
 func (*TopologyNode) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{10}
+	return file_mesh_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TopologyNode) GetId() string {
@@ -684,7 +967,7 @@ type Service struct {
 
 func (x *Service) Reset() {
 	*x = Service{}
-	mi := &file_mesh_proto_msgTypes[11]
+	mi := &file_mesh_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -696,7 +979,7 @@ func (x *Service) String() string {
 func (*Service) ProtoMessage() {}
 
 func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_mesh_proto_msgTypes[11]
+	mi := &file_mesh_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,7 +992,7 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Service.ProtoReflect.Descriptor instead.
 func (*Service) Descriptor() ([]byte, []int) {
-	return file_mesh_proto_rawDescGZIP(), []int{11}
+	return file_mesh_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Service) GetName() string {
@@ -726,28 +1009,407 @@ func (x *Service) GetVersion() string {
 	return ""
 }
 
+// Broadcast messages
+type BroadcastRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	OriginId      string                 `protobuf:"bytes,2,opt,name=origin_id,json=originId,proto3" json:"origin_id,omitempty"`
+	SenderId      string                 `protobuf:"bytes,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Ttl           int32                  `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastRequest) Reset() {
+	*x = BroadcastRequest{}
+	mi := &file_mesh_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRequest) ProtoMessage() {}
+
+func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastRequest) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BroadcastRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *BroadcastRequest) GetOriginId() string {
+	if x != nil {
+		return x.OriginId
+	}
+	return ""
+}
+
+func (x *BroadcastRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *BroadcastRequest) GetTtl() int32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *BroadcastRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *BroadcastRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type BroadcastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceiverId    string                 `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastResponse) Reset() {
+	*x = BroadcastResponse{}
+	mi := &file_mesh_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastResponse) ProtoMessage() {}
+
+func (x *BroadcastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastResponse) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BroadcastResponse) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+func (x *BroadcastResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+// Capability messages
+type CapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapabilitiesRequest) Reset() {
+	*x = CapabilitiesRequest{}
+	mi := &file_mesh_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilitiesRequest) ProtoMessage() {}
+
+func (x *CapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CapabilitiesRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+type CapabilitiesResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NodeId          string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ProtocolVersion int32                  `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Features        []string               `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CapabilitiesResponse) Reset() {
+	*x = CapabilitiesResponse{}
+	mi := &file_mesh_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilitiesResponse) ProtoMessage() {}
+
+func (x *CapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CapabilitiesResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *CapabilitiesResponse) GetProtocolVersion() int32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *CapabilitiesResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// Load messages
+type LoadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SenderId      string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadRequest) Reset() {
+	*x = LoadRequest{}
+	mi := &file_mesh_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadRequest) ProtoMessage() {}
+
+func (x *LoadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadRequest.ProtoReflect.Descriptor instead.
+func (*LoadRequest) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LoadRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+type LoadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	InFlight      int64                  `protobuf:"varint,2,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	Goroutines    int64                  `protobuf:"varint,3,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	HeapBytes     uint64                 `protobuf:"varint,4,opt,name=heap_bytes,json=heapBytes,proto3" json:"heap_bytes,omitempty"`
+	NumCpu        int64                  `protobuf:"varint,5,opt,name=num_cpu,json=numCpu,proto3" json:"num_cpu,omitempty"`
+	HealthStatus  string                 `protobuf:"bytes,6,opt,name=health_status,json=healthStatus,proto3" json:"health_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadResponse) Reset() {
+	*x = LoadResponse{}
+	mi := &file_mesh_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadResponse) ProtoMessage() {}
+
+func (x *LoadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mesh_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadResponse.ProtoReflect.Descriptor instead.
+func (*LoadResponse) Descriptor() ([]byte, []int) {
+	return file_mesh_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LoadResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *LoadResponse) GetInFlight() int64 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *LoadResponse) GetGoroutines() int64 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *LoadResponse) GetHeapBytes() uint64 {
+	if x != nil {
+		return x.HeapBytes
+	}
+	return 0
+}
+
+func (x *LoadResponse) GetNumCpu() int64 {
+	if x != nil {
+		return x.NumCpu
+	}
+	return 0
+}
+
+func (x *LoadResponse) GetHealthStatus() string {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return ""
+}
+
 var File_mesh_proto protoreflect.FileDescriptor
 
 const file_mesh_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"mesh.proto\x12\x05aegis\"H\n" +
+	"mesh.proto\x12\x05aegis\"s\n" +
 	"\vPingRequest\x12\x1b\n" +
 	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12\x1c\n" +
-	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\"g\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12)\n" +
+	"\x10protocol_version\x18\x03 \x01(\x05R\x0fprotocolVersion\"\x92\x01\n" +
 	"\fPingResponse\x12\x1f\n" +
 	"\vreceiver_id\x18\x01 \x01(\tR\n" +
 	"receiverId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x18\n" +
-	"\asuccess\x18\x03 \x01(\bR\asuccess\",\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12)\n" +
+	"\x10protocol_version\x18\x04 \x01(\x05R\x0fprotocolVersion\",\n" +
 	"\rHealthRequest\x12\x1b\n" +
-	"\tsender_id\x18\x01 \x01(\tR\bsenderId\"\x94\x01\n" +
+	"\tsender_id\x18\x01 \x01(\tR\bsenderId\"\xe9\x01\n" +
 	"\x0eHealthResponse\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
 	"\flast_checked\x18\x03 \x01(\x03R\vlastChecked\x12\x18\n" +
 	"\amessage\x18\x04 \x01(\tR\amessage\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\".\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x127\n" +
+	"\ahistory\x18\x06 \x03(\v2\x1d.aegis.HealthStatusTransitionR\ahistory\x12\x1a\n" +
+	"\bdraining\x18\a \x01(\bR\bdraining\"h\n" +
+	"\x16HealthStatusTransition\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\".\n" +
 	"\x0fNodeInfoRequest\x12\x1b\n" +
 	"\tsender_id\x18\x01 \x01(\tR\bsenderId\"\x93\x01\n" +
 	"\x10NodeInfoResponse\x12\x0e\n" +
@@ -755,15 +1417,32 @@ const file_mesh_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
 	"\aaddress\x18\x04 \x01(\tR\aaddress\x12-\n" +
-	"\x06health\x18\x05 \x01(\v2\x15.aegis.HealthResponseR\x06health\"L\n" +
+	"\x06health\x18\x05 \x01(\v2\x15.aegis.HealthResponseR\x06health\"\xae\x01\n" +
 	"\x13TopologySyncRequest\x12\x1b\n" +
 	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12\x18\n" +
-	"\aversion\x18\x02 \x01(\x03R\aversion\"z\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12!\n" +
+	"\faccept_delta\x18\x03 \x01(\bR\vacceptDelta\x12\x18\n" +
+	"\alineage\x18\x04 \x01(\tR\alineage\x12#\n" +
+	"\raccept_stream\x18\x05 \x01(\bR\facceptStream\"\x84\x02\n" +
 	"\x14TopologySyncResponse\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x03R\aversion\x12)\n" +
 	"\x05nodes\x18\x02 \x03(\v2\x13.aegis.TopologyNodeR\x05nodes\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\"1\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\bR\x05delta\x12/\n" +
+	"\achanges\x18\x05 \x03(\v2\x15.aegis.TopologyChangeR\achanges\x12\x18\n" +
+	"\alineage\x18\x06 \x01(\tR\alineage\x12'\n" +
+	"\x0fstream_required\x18\a \x01(\bR\x0estreamRequired\"g\n" +
+	"\x0eTopologyChange\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12'\n" +
+	"\x04node\x18\x03 \x01(\v2\x13.aegis.TopologyNodeR\x04node\"\x91\x01\n" +
+	"\x11TopologySyncChunk\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12)\n" +
+	"\x05nodes\x18\x02 \x03(\v2\x13.aegis.TopologyNodeR\x05nodes\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\x12\x18\n" +
+	"\alineage\x18\x04 \x01(\tR\alineage\"1\n" +
 	"\x12GetTopologyRequest\x12\x1b\n" +
 	"\tsender_id\x18\x01 \x01(\tR\bsenderId\"Z\n" +
 	"\x13GetTopologyResponse\x12\x18\n" +
@@ -780,13 +1459,47 @@ const file_mesh_proto_rawDesc = "" +
 	"\bservices\x18\a \x03(\v2\x0e.aegis.ServiceR\bservices\"7\n" +
 	"\aService\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
-	"\aversion\x18\x02 \x01(\tR\aversion2\xc7\x02\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"\xb5\x01\n" +
+	"\x10BroadcastRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\torigin_id\x18\x02 \x01(\tR\boriginId\x12\x1b\n" +
+	"\tsender_id\x18\x03 \x01(\tR\bsenderId\x12\x10\n" +
+	"\x03ttl\x18\x04 \x01(\x05R\x03ttl\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\"P\n" +
+	"\x11BroadcastResponse\x12\x1f\n" +
+	"\vreceiver_id\x18\x01 \x01(\tR\n" +
+	"receiverId\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\"2\n" +
+	"\x13CapabilitiesRequest\x12\x1b\n" +
+	"\tsender_id\x18\x01 \x01(\tR\bsenderId\"v\n" +
+	"\x14CapabilitiesResponse\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12)\n" +
+	"\x10protocol_version\x18\x02 \x01(\x05R\x0fprotocolVersion\x12\x1a\n" +
+	"\bfeatures\x18\x03 \x03(\tR\bfeatures\"*\n" +
+	"\vLoadRequest\x12\x1b\n" +
+	"\tsender_id\x18\x01 \x01(\tR\bsenderId\"\xc1\x01\n" +
+	"\fLoadResponse\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tin_flight\x18\x02 \x01(\x03R\binFlight\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\x03 \x01(\x03R\n" +
+	"goroutines\x12\x1d\n" +
+	"\n" +
+	"heap_bytes\x18\x04 \x01(\x04R\theapBytes\x12\x17\n" +
+	"\anum_cpu\x18\x05 \x01(\x03R\x06numCpu\x12#\n" +
+	"\rhealth_status\x18\x06 \x01(\tR\fhealthStatus2\xd5\x04\n" +
 	"\vMeshService\x12/\n" +
 	"\x04Ping\x12\x12.aegis.PingRequest\x1a\x13.aegis.PingResponse\x128\n" +
 	"\tGetHealth\x12\x14.aegis.HealthRequest\x1a\x15.aegis.HealthResponse\x12>\n" +
 	"\vGetNodeInfo\x12\x16.aegis.NodeInfoRequest\x1a\x17.aegis.NodeInfoResponse\x12G\n" +
 	"\fSyncTopology\x12\x1a.aegis.TopologySyncRequest\x1a\x1b.aegis.TopologySyncResponse\x12D\n" +
-	"\vGetTopology\x12\x19.aegis.GetTopologyRequest\x1a\x1a.aegis.GetTopologyResponseB\x1bZ\x19github.com/zoobz-io/aegisb\x06proto3"
+	"\vGetTopology\x12\x19.aegis.GetTopologyRequest\x1a\x1a.aegis.GetTopologyResponse\x12L\n" +
+	"\x12SyncTopologyStream\x12\x1a.aegis.TopologySyncRequest\x1a\x18.aegis.TopologySyncChunk0\x01\x12>\n" +
+	"\tBroadcast\x12\x17.aegis.BroadcastRequest\x1a\x18.aegis.BroadcastResponse\x12J\n" +
+	"\x0fGetCapabilities\x12\x1a.aegis.CapabilitiesRequest\x1a\x1b.aegis.CapabilitiesResponse\x122\n" +
+	"\aGetLoad\x12\x12.aegis.LoadRequest\x1a\x13.aegis.LoadResponseB\x1bZ\x19github.com/zoobz-io/aegisb\x06proto3"
 
 var (
 	file_mesh_proto_rawDescOnce sync.Once
@@ -800,41 +1513,62 @@ func file_mesh_proto_rawDescGZIP() []byte {
 	return file_mesh_proto_rawDescData
 }
 
-var file_mesh_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_mesh_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_mesh_proto_goTypes = []any{
-	(*PingRequest)(nil),          // 0: aegis.PingRequest
-	(*PingResponse)(nil),         // 1: aegis.PingResponse
-	(*HealthRequest)(nil),        // 2: aegis.HealthRequest
-	(*HealthResponse)(nil),       // 3: aegis.HealthResponse
-	(*NodeInfoRequest)(nil),      // 4: aegis.NodeInfoRequest
-	(*NodeInfoResponse)(nil),     // 5: aegis.NodeInfoResponse
-	(*TopologySyncRequest)(nil),  // 6: aegis.TopologySyncRequest
-	(*TopologySyncResponse)(nil), // 7: aegis.TopologySyncResponse
-	(*GetTopologyRequest)(nil),   // 8: aegis.GetTopologyRequest
-	(*GetTopologyResponse)(nil),  // 9: aegis.GetTopologyResponse
-	(*TopologyNode)(nil),         // 10: aegis.TopologyNode
-	(*Service)(nil),              // 11: aegis.Service
+	(*PingRequest)(nil),            // 0: aegis.PingRequest
+	(*PingResponse)(nil),           // 1: aegis.PingResponse
+	(*HealthRequest)(nil),          // 2: aegis.HealthRequest
+	(*HealthResponse)(nil),         // 3: aegis.HealthResponse
+	(*HealthStatusTransition)(nil), // 4: aegis.HealthStatusTransition
+	(*NodeInfoRequest)(nil),        // 5: aegis.NodeInfoRequest
+	(*NodeInfoResponse)(nil),       // 6: aegis.NodeInfoResponse
+	(*TopologySyncRequest)(nil),    // 7: aegis.TopologySyncRequest
+	(*TopologySyncResponse)(nil),   // 8: aegis.TopologySyncResponse
+	(*TopologyChange)(nil),         // 9: aegis.TopologyChange
+	(*TopologySyncChunk)(nil),      // 10: aegis.TopologySyncChunk
+	(*GetTopologyRequest)(nil),     // 11: aegis.GetTopologyRequest
+	(*GetTopologyResponse)(nil),    // 12: aegis.GetTopologyResponse
+	(*TopologyNode)(nil),           // 13: aegis.TopologyNode
+	(*Service)(nil),                // 14: aegis.Service
+	(*BroadcastRequest)(nil),       // 15: aegis.BroadcastRequest
+	(*BroadcastResponse)(nil),      // 16: aegis.BroadcastResponse
+	(*CapabilitiesRequest)(nil),    // 17: aegis.CapabilitiesRequest
+	(*CapabilitiesResponse)(nil),   // 18: aegis.CapabilitiesResponse
+	(*LoadRequest)(nil),            // 19: aegis.LoadRequest
+	(*LoadResponse)(nil),           // 20: aegis.LoadResponse
 }
 var file_mesh_proto_depIdxs = []int32{
-	3,  // 0: aegis.NodeInfoResponse.health:type_name -> aegis.HealthResponse
-	10, // 1: aegis.TopologySyncResponse.nodes:type_name -> aegis.TopologyNode
-	10, // 2: aegis.GetTopologyResponse.nodes:type_name -> aegis.TopologyNode
-	11, // 3: aegis.TopologyNode.services:type_name -> aegis.Service
-	0,  // 4: aegis.MeshService.Ping:input_type -> aegis.PingRequest
-	2,  // 5: aegis.MeshService.GetHealth:input_type -> aegis.HealthRequest
-	4,  // 6: aegis.MeshService.GetNodeInfo:input_type -> aegis.NodeInfoRequest
-	6,  // 7: aegis.MeshService.SyncTopology:input_type -> aegis.TopologySyncRequest
-	8,  // 8: aegis.MeshService.GetTopology:input_type -> aegis.GetTopologyRequest
-	1,  // 9: aegis.MeshService.Ping:output_type -> aegis.PingResponse
-	3,  // 10: aegis.MeshService.GetHealth:output_type -> aegis.HealthResponse
-	5,  // 11: aegis.MeshService.GetNodeInfo:output_type -> aegis.NodeInfoResponse
-	7,  // 12: aegis.MeshService.SyncTopology:output_type -> aegis.TopologySyncResponse
-	9,  // 13: aegis.MeshService.GetTopology:output_type -> aegis.GetTopologyResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	4,  // 0: aegis.HealthResponse.history:type_name -> aegis.HealthStatusTransition
+	3,  // 1: aegis.NodeInfoResponse.health:type_name -> aegis.HealthResponse
+	13, // 2: aegis.TopologySyncResponse.nodes:type_name -> aegis.TopologyNode
+	9,  // 3: aegis.TopologySyncResponse.changes:type_name -> aegis.TopologyChange
+	13, // 4: aegis.TopologyChange.node:type_name -> aegis.TopologyNode
+	13, // 5: aegis.TopologySyncChunk.nodes:type_name -> aegis.TopologyNode
+	13, // 6: aegis.GetTopologyResponse.nodes:type_name -> aegis.TopologyNode
+	14, // 7: aegis.TopologyNode.services:type_name -> aegis.Service
+	0,  // 8: aegis.MeshService.Ping:input_type -> aegis.PingRequest
+	2,  // 9: aegis.MeshService.GetHealth:input_type -> aegis.HealthRequest
+	5,  // 10: aegis.MeshService.GetNodeInfo:input_type -> aegis.NodeInfoRequest
+	7,  // 11: aegis.MeshService.SyncTopology:input_type -> aegis.TopologySyncRequest
+	11, // 12: aegis.MeshService.GetTopology:input_type -> aegis.GetTopologyRequest
+	7,  // 13: aegis.MeshService.SyncTopologyStream:input_type -> aegis.TopologySyncRequest
+	15, // 14: aegis.MeshService.Broadcast:input_type -> aegis.BroadcastRequest
+	17, // 15: aegis.MeshService.GetCapabilities:input_type -> aegis.CapabilitiesRequest
+	19, // 16: aegis.MeshService.GetLoad:input_type -> aegis.LoadRequest
+	1,  // 17: aegis.MeshService.Ping:output_type -> aegis.PingResponse
+	3,  // 18: aegis.MeshService.GetHealth:output_type -> aegis.HealthResponse
+	6,  // 19: aegis.MeshService.GetNodeInfo:output_type -> aegis.NodeInfoResponse
+	8,  // 20: aegis.MeshService.SyncTopology:output_type -> aegis.TopologySyncResponse
+	12, // 21: aegis.MeshService.GetTopology:output_type -> aegis.GetTopologyResponse
+	10, // 22: aegis.MeshService.SyncTopologyStream:output_type -> aegis.TopologySyncChunk
+	16, // 23: aegis.MeshService.Broadcast:output_type -> aegis.BroadcastResponse
+	18, // 24: aegis.MeshService.GetCapabilities:output_type -> aegis.CapabilitiesResponse
+	20, // 25: aegis.MeshService.GetLoad:output_type -> aegis.LoadResponse
+	17, // [17:26] is the sub-list for method output_type
+	8,  // [8:17] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_mesh_proto_init() }
@@ -848,7 +1582,7 @@ func file_mesh_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mesh_proto_rawDesc), len(file_mesh_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
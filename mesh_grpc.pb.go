@@ -19,11 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MeshService_Ping_FullMethodName         = "/aegis.MeshService/Ping"
-	MeshService_GetHealth_FullMethodName    = "/aegis.MeshService/GetHealth"
-	MeshService_GetNodeInfo_FullMethodName  = "/aegis.MeshService/GetNodeInfo"
-	MeshService_SyncTopology_FullMethodName = "/aegis.MeshService/SyncTopology"
-	MeshService_GetTopology_FullMethodName  = "/aegis.MeshService/GetTopology"
+	MeshService_Ping_FullMethodName               = "/aegis.MeshService/Ping"
+	MeshService_GetHealth_FullMethodName          = "/aegis.MeshService/GetHealth"
+	MeshService_GetNodeInfo_FullMethodName        = "/aegis.MeshService/GetNodeInfo"
+	MeshService_SyncTopology_FullMethodName       = "/aegis.MeshService/SyncTopology"
+	MeshService_GetTopology_FullMethodName        = "/aegis.MeshService/GetTopology"
+	MeshService_SyncTopologyStream_FullMethodName = "/aegis.MeshService/SyncTopologyStream"
+	MeshService_Broadcast_FullMethodName          = "/aegis.MeshService/Broadcast"
+	MeshService_GetCapabilities_FullMethodName    = "/aegis.MeshService/GetCapabilities"
+	MeshService_GetLoad_FullMethodName            = "/aegis.MeshService/GetLoad"
 )
 
 // MeshServiceClient is the client API for MeshService service.
@@ -37,6 +41,13 @@ type MeshServiceClient interface {
 	// Topology operations
 	SyncTopology(ctx context.Context, in *TopologySyncRequest, opts ...grpc.CallOption) (*TopologySyncResponse, error)
 	GetTopology(ctx context.Context, in *GetTopologyRequest, opts ...grpc.CallOption) (*GetTopologyResponse, error)
+	SyncTopologyStream(ctx context.Context, in *TopologySyncRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TopologySyncChunk], error)
+	// Broadcast operations
+	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	// Capability discovery
+	GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+	// Load reporting
+	GetLoad(ctx context.Context, in *LoadRequest, opts ...grpc.CallOption) (*LoadResponse, error)
 }
 
 type meshServiceClient struct {
@@ -97,6 +108,55 @@ func (c *meshServiceClient) GetTopology(ctx context.Context, in *GetTopologyRequ
 	return out, nil
 }
 
+func (c *meshServiceClient) SyncTopologyStream(ctx context.Context, in *TopologySyncRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TopologySyncChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MeshService_ServiceDesc.Streams[0], MeshService_SyncTopologyStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TopologySyncRequest, TopologySyncChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MeshService_SyncTopologyStreamClient = grpc.ServerStreamingClient[TopologySyncChunk]
+
+func (c *meshServiceClient) Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, MeshService_Broadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshServiceClient) GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, MeshService_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshServiceClient) GetLoad(ctx context.Context, in *LoadRequest, opts ...grpc.CallOption) (*LoadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadResponse)
+	err := c.cc.Invoke(ctx, MeshService_GetLoad_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MeshServiceServer is the server API for MeshService service.
 // All implementations must embed UnimplementedMeshServiceServer
 // for forward compatibility.
@@ -108,6 +168,13 @@ type MeshServiceServer interface {
 	// Topology operations
 	SyncTopology(context.Context, *TopologySyncRequest) (*TopologySyncResponse, error)
 	GetTopology(context.Context, *GetTopologyRequest) (*GetTopologyResponse, error)
+	SyncTopologyStream(*TopologySyncRequest, grpc.ServerStreamingServer[TopologySyncChunk]) error
+	// Broadcast operations
+	Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
+	// Capability discovery
+	GetCapabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+	// Load reporting
+	GetLoad(context.Context, *LoadRequest) (*LoadResponse, error)
 	mustEmbedUnimplementedMeshServiceServer()
 }
 
@@ -133,6 +200,18 @@ func (UnimplementedMeshServiceServer) SyncTopology(context.Context, *TopologySyn
 func (UnimplementedMeshServiceServer) GetTopology(context.Context, *GetTopologyRequest) (*GetTopologyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTopology not implemented")
 }
+func (UnimplementedMeshServiceServer) SyncTopologyStream(*TopologySyncRequest, grpc.ServerStreamingServer[TopologySyncChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method SyncTopologyStream not implemented")
+}
+func (UnimplementedMeshServiceServer) Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (UnimplementedMeshServiceServer) GetCapabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedMeshServiceServer) GetLoad(context.Context, *LoadRequest) (*LoadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoad not implemented")
+}
 func (UnimplementedMeshServiceServer) mustEmbedUnimplementedMeshServiceServer() {}
 func (UnimplementedMeshServiceServer) testEmbeddedByValue()                     {}
 
@@ -244,6 +323,71 @@ func _MeshService_GetTopology_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_SyncTopologyStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TopologySyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MeshServiceServer).SyncTopologyStream(m, &grpc.GenericServerStream[TopologySyncRequest, TopologySyncChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MeshService_SyncTopologyStreamServer = grpc.ServerStreamingServer[TopologySyncChunk]
+
+func _MeshService_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshService_Broadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).Broadcast(ctx, req.(*BroadcastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeshService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).GetCapabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeshService_GetLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).GetLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshService_GetLoad_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).GetLoad(ctx, req.(*LoadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MeshService_ServiceDesc is the grpc.ServiceDesc for MeshService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,7 +415,25 @@ var MeshService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTopology",
 			Handler:    _MeshService_GetTopology_Handler,
 		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _MeshService_Broadcast_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _MeshService_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetLoad",
+			Handler:    _MeshService_GetLoad_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SyncTopologyStream",
+			Handler:       _MeshService_SyncTopologyStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "mesh.proto",
 }
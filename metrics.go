@@ -0,0 +1,190 @@
+package aegis
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metricsContentType is the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// latencyBuckets are the upper bounds, in seconds, of the peer call latency histogram.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MetricsHandler returns an HTTP handler that exposes node metrics in
+// Prometheus text format.
+func (n *Node) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", metricsContentType)
+		_, _ = w.Write(n.renderMetrics())
+	})
+}
+
+// renderMetrics writes the node's current metrics in Prometheus text format.
+func (n *Node) renderMetrics() []byte {
+	var buf bytes.Buffer
+	node := fmt.Sprintf("node_id=%q", n.ID)
+
+	writeMetric(&buf, "aegis_node_info", "gauge", "Static information about the node.",
+		fmt.Sprintf("%s,name=%q,type=%q", node, n.Name, n.Type), 1)
+
+	if n.Health != nil {
+		status, lastChecked, _, _ := n.Health.Get()
+		fmt.Fprintf(&buf, "# HELP aegis_health_status Current health status of the node (1 for the active status).\n")
+		fmt.Fprintf(&buf, "# TYPE aegis_health_status gauge\n")
+		for _, s := range []HealthStatus{HealthStatusHealthy, HealthStatusUnhealthy, HealthStatusUnknown} {
+			value := 0
+			if s == status {
+				value = 1
+			}
+			fmt.Fprintf(&buf, "aegis_health_status{%s,status=%q} %d\n", node, s, value)
+		}
+		writeMetric(&buf, "aegis_health_last_checked_seconds", "gauge",
+			"Unix time of the last health check.", node, lastChecked.Unix())
+		writeMetric(&buf, "aegis_health_transitions_total", "counter",
+			"Total number of health status transitions.", node, n.Health.Transitions())
+	}
+
+	if n.Topology != nil {
+		writeMetric(&buf, "aegis_topology_version", "gauge",
+			"Current topology version.", node, n.Topology.GetVersion())
+		writeMetric(&buf, "aegis_topology_nodes", "gauge",
+			"Number of nodes in the topology.", node, n.Topology.NodeCount())
+	}
+
+	if n.PeerManager != nil {
+		peers := n.PeerManager.GetAllPeers()
+		sort.Slice(peers, func(i, j int) bool { return peers[i].Info.ID < peers[j].Info.ID })
+
+		writeMetric(&buf, "aegis_peers", "gauge", "Number of configured peers.", node, len(peers))
+
+		fmt.Fprintf(&buf, "# HELP aegis_peer_connected Whether the peer connection is READY.\n")
+		fmt.Fprintf(&buf, "# TYPE aegis_peer_connected gauge\n")
+		for _, peer := range peers {
+			value := 0
+			if n.PeerManager.IsConnected(peer.Info.ID) {
+				value = 1
+			}
+			fmt.Fprintf(&buf, "aegis_peer_connected{%s,peer_id=%q} %d\n", node, peer.Info.ID, value)
+		}
+
+		fmt.Fprintf(&buf, "# HELP aegis_peer_tls_info TLS version and cipher suite negotiated with the peer.\n")
+		fmt.Fprintf(&buf, "# TYPE aegis_peer_tls_info gauge\n")
+		for _, peer := range peers {
+			info, ok := n.PeerManager.PeerTLS(peer.Info.ID)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&buf, "aegis_peer_tls_info{%s,peer_id=%q,version=%q,cipher_suite=%q} 1\n",
+				node, peer.Info.ID, info.VersionName(), info.CipherSuiteName())
+		}
+
+		fmt.Fprintf(&buf, "# HELP aegis_peer_call_duration_seconds Latency of unary calls to the peer.\n")
+		fmt.Fprintf(&buf, "# TYPE aegis_peer_call_duration_seconds histogram\n")
+		for _, peer := range peers {
+			if peer.latency != nil {
+				peer.latency.write(&buf, fmt.Sprintf("%s,peer_id=%q", node, peer.Info.ID))
+			}
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// writeMetric writes a single-sample metric with its HELP and TYPE lines.
+func writeMetric[V int | int64 | uint64](buf *bytes.Buffer, name, kind, help, labels string, value V) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buf, "# TYPE %s %s\n", name, kind)
+	fmt.Fprintf(buf, "%s{%s} %d\n", name, labels, value)
+}
+
+// latencySeries holds the observations for one method and status code.
+type latencySeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// latencyKey identifies a latency series.
+type latencyKey struct {
+	method string
+	code   string
+}
+
+// latencyHistogram records call latency to a single peer.
+type latencyHistogram struct {
+	series map[latencyKey]*latencySeries
+	mu     sync.Mutex
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{series: make(map[latencyKey]*latencySeries)}
+}
+
+// observe records a call that took d and finished with err.
+func (h *latencyHistogram) observe(method string, err error, d time.Duration) {
+	key := latencyKey{method: method, code: status.Code(err).String()}
+	seconds := d.Seconds()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &latencySeries{counts: make([]uint64, len(latencyBuckets))}
+		h.series[key] = s
+	}
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += seconds
+}
+
+// write renders the histogram's samples, sorted by method and code.
+func (h *latencyHistogram) write(buf *bytes.Buffer, labels string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]latencyKey, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	const name = "aegis_peer_call_duration_seconds"
+	for _, key := range keys {
+		s := h.series[key]
+		series := fmt.Sprintf("%s,method=%q,code=%q", labels, key.method, key.code)
+		for i, bound := range latencyBuckets {
+			fmt.Fprintf(buf, "%s_bucket{%s,le=%q} %d\n", name, series, strconv.FormatFloat(bound, 'g', -1, 64), s.counts[i])
+		}
+		fmt.Fprintf(buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, series, s.count)
+		fmt.Fprintf(buf, "%s_sum{%s} %s\n", name, series, strconv.FormatFloat(s.sum, 'g', -1, 64))
+		fmt.Fprintf(buf, "%s_count{%s} %d\n", name, series, s.count)
+	}
+}
+
+// unaryInterceptor records the latency of each unary call.
+func (h *latencyHistogram) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	h.observe(method, err, time.Since(start))
+	return err
+}
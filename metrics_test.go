@@ -0,0 +1,107 @@
+package aegis
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestNodeMetricsHandler(t *testing.T) {
+	node := NewNode("node-1", "Node 1", NodeTypeGeneric, "localhost:8080")
+	node.SetHealth(HealthStatusHealthy, "ok", nil)
+
+	rec := httptest.NewRecorder()
+	node.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("expected text/plain content type, got '%s'", ct)
+	}
+
+	body := rec.Body.String()
+	expected := []string{
+		`# TYPE aegis_topology_nodes gauge`,
+		`aegis_node_info{node_id="node-1",name="Node 1",type="generic"} 1`,
+		`aegis_health_status{node_id="node-1",status="healthy"} 1`,
+		`aegis_health_status{node_id="node-1",status="unhealthy"} 0`,
+		`aegis_topology_version{node_id="node-1"} 1`,
+		`aegis_topology_nodes{node_id="node-1"} 1`,
+		`aegis_peers{node_id="node-1"} 0`,
+		`# TYPE aegis_health_transitions_total counter`,
+		`aegis_health_transitions_total{node_id="node-1"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected metrics output to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestNodeMetricsPeerTLSInfo(t *testing.T) {
+	node := NewNode("node-1", "Node 1", NodeTypeGeneric, "localhost:8080")
+	conn, err := grpc.NewClient("localhost:1", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	node.PeerManager.peers["peer-1"] = &Peer{
+		Info:    PeerInfo{ID: "peer-1"},
+		Conn:    conn,
+		tlsInfo: &TLSConnectionInfo{Version: tls.VersionTLS13, CipherSuite: tls.TLS_AES_128_GCM_SHA256},
+	}
+
+	body := string(node.renderMetrics())
+	expected := `aegis_peer_tls_info{node_id="node-1",peer_id="peer-1",version="TLS 1.3",cipher_suite="TLS_AES_128_GCM_SHA256"} 1`
+	if !strings.Contains(body, expected+"\n") {
+		t.Errorf("expected metrics output to contain %q, got:\n%s", expected, body)
+	}
+}
+
+func TestNodeMetricsPeerCallLatency(t *testing.T) {
+	node := NewNode("node-1", "Node 1", NodeTypeGeneric, "localhost:8080")
+	conn, err := grpc.NewClient("localhost:1", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	latency := newLatencyHistogram()
+	node.PeerManager.peers["peer-1"] = &Peer{
+		Info:    PeerInfo{ID: "peer-1"},
+		Conn:    conn,
+		latency: latency,
+	}
+
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	if err := latency.unaryInterceptor(context.Background(), "/aegis.MeshService/Ping", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	latency.observe("/aegis.MeshService/Ping", nil, 3*time.Second)
+
+	body := string(node.renderMetrics())
+	series := `node_id="node-1",peer_id="peer-1",method="/aegis.MeshService/Ping",code="OK"`
+	expected := []string{
+		`# TYPE aegis_peer_call_duration_seconds histogram`,
+		`aegis_peer_call_duration_seconds_bucket{` + series + `,le="2.5"} 1`,
+		`aegis_peer_call_duration_seconds_bucket{` + series + `,le="5"} 2`,
+		`aegis_peer_call_duration_seconds_bucket{` + series + `,le="+Inf"} 2`,
+		`aegis_peer_call_duration_seconds_count{` + series + `} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected metrics output to contain %q, got:\n%s", line, body)
+		}
+	}
+}
@@ -3,9 +3,30 @@ package aegis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// initialSyncTimeout bounds the background topology sync run when a peer is added.
+	initialSyncTimeout = 10 * time.Second
+	// shutdownDrainTimeout bounds how long Shutdown waits for in-flight RPCs.
+	shutdownDrainTimeout = 10 * time.Second
+	// topologyStreamThreshold is the topology size at which peers serve SyncTopology by streaming.
+	topologyStreamThreshold = 1000
+)
+
+const (
+	// DefaultTopologySyncConcurrency is the default number of peers SyncTopologyWithAllPeers syncs at once.
+	DefaultTopologySyncConcurrency = 8
+	// DefaultTopologySyncTimeout is the default time SyncTopologyWithAllPeers allows each peer.
+	DefaultTopologySyncTimeout = 30 * time.Second
 )
 
 // NodeType represents the type of node in the mesh.
@@ -28,6 +49,21 @@ type Node struct {
 	MeshServer  *MeshServer  `json:"-"`
 	Topology    *Topology    `json:"-"`
 	TLSConfig   *TLSConfig   `json:"-"`
+
+	tracerProvider   trace.TracerProvider
+	maxMessageSize   int
+	broadcastCache   *messageCache
+	relaySlots       chan struct{}
+	broadcastHandler BroadcastHandler
+	versionHandler   VersionMismatchHandler
+	drainingHandler  func()
+	syncHandler      InitialSyncHandler
+	topologyHandler  TopologyChangedHandler
+	loadScorer       LoadScorer
+	syncConcurrency  int
+	syncTimeout      time.Duration
+	initialSyncs     sync.WaitGroup
+	mu               sync.RWMutex
 }
 
 // NewNode creates a new mesh node.
@@ -38,6 +74,9 @@ func NewNode(id, name string, nodeType NodeType, address string) *Node {
 		Type:    nodeType,
 		Address: address,
 		Health:  NewHealthInfo(),
+
+		broadcastCache: newMessageCache(DefaultBroadcastCacheSize),
+		relaySlots:     make(chan struct{}, maxConcurrentRelays),
 	}
 
 	node.PeerManager = NewPeerManager(id)
@@ -187,6 +226,134 @@ func (n *Node) AddPeer(info PeerInfo) error {
 	return n.PeerManager.AddPeer(info)
 }
 
+// AddPeers adds multiple peer connections and waits until each is reachable,
+// returning the result for each peer ID. Unreachable and incompatible peers
+// are not kept; incompatible peers trigger the OnVersionMismatch handler.
+func (n *Node) AddPeers(ctx context.Context, infos []PeerInfo) map[string]error {
+	if n.PeerManager == nil {
+		results := make(map[string]error, len(infos))
+		for _, info := range infos {
+			results[info.ID] = fmt.Errorf("peer manager not initialized")
+		}
+		return results
+	}
+	results := n.PeerManager.AddPeers(ctx, infos)
+	for _, err := range results {
+		n.notifyVersionMismatchError(err)
+	}
+	return results
+}
+
+// AddPeerAndSync adds a peer connection and pulls its topology in the background.
+// The add does not wait for the sync; a failed initial sync is left for the next
+// regular sync to recover and does not undo the add. The sync carries the request
+// ID of ctx but is not cancelled with it; it stops if the peer is removed, and
+// Shutdown waits for it.
+func (n *Node) AddPeerAndSync(ctx context.Context, info PeerInfo) error {
+	if err := n.AddPeer(info); err != nil {
+		return err
+	}
+
+	peer, exists := n.GetPeer(info.ID)
+	if !exists {
+		return nil
+	}
+
+	requestID := RequestID(ensureRequestID(ctx))
+	n.initialSyncs.Add(1)
+	go func() {
+		defer n.initialSyncs.Done()
+		ctx, cancel := context.WithTimeout(WithRequestID(context.Background(), requestID), initialSyncTimeout)
+		defer cancel()
+		stop := context.AfterFunc(peer.ctx, cancel)
+		defer stop()
+
+		n.notifyInitialSync(info.ID, n.initialSync(ctx, info.ID))
+	}()
+
+	return nil
+}
+
+// initialSync negotiates versions with a newly added peer and pulls its topology.
+func (n *Node) initialSync(ctx context.Context, peerID string) error {
+	// Negotiate versions first so incompatible peers are not synced from.
+	_, pingErr := n.PingPeer(ctx, peerID)
+	if errors.Is(pingErr, ErrVersionMismatch) {
+		return pingErr
+	}
+	if err := n.SyncTopology(ctx, peerID); err != nil {
+		if pingErr != nil {
+			return fmt.Errorf("initial sync with peer %s failed: %w (ping: %v)", peerID, err, pingErr)
+		}
+		return fmt.Errorf("initial sync with peer %s failed: %w", peerID, err)
+	}
+	return nil
+}
+
+// InitialSyncHandler is called when the background sync started by
+// AddPeerAndSync finishes; err is nil on success.
+type InitialSyncHandler func(peerID string, err error)
+
+// OnInitialSync sets the handler invoked when the background sync started by
+// AddPeerAndSync finishes. A failed sync does not remove the peer.
+func (n *Node) OnInitialSync(handler InitialSyncHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncHandler = handler
+}
+
+// notifyInitialSync invokes the initial sync handler, if set.
+func (n *Node) notifyInitialSync(peerID string, err error) {
+	n.mu.RLock()
+	handler := n.syncHandler
+	n.mu.RUnlock()
+
+	if handler != nil {
+		handler(peerID, err)
+	}
+}
+
+// TopologyUpdate describes a sync with a peer that grew the local topology.
+type TopologyUpdate struct {
+	PeerID    string
+	Version   int64
+	Added     int
+	NodeCount int
+}
+
+// TopologyChangedHandler is called when syncing with a peer adds nodes to the topology.
+type TopologyChangedHandler func(update TopologyUpdate)
+
+// OnTopologyChanged sets the handler invoked when SyncTopology or
+// SyncTopologyStream increases the number of nodes in the topology.
+func (n *Node) OnTopologyChanged(handler TopologyChangedHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.topologyHandler = handler
+}
+
+// notifyTopologyGrowth invokes the topology changed handler if the topology
+// holds more nodes than before.
+func (n *Node) notifyTopologyGrowth(peerID string, before int) {
+	count := n.Topology.NodeCount()
+	if count <= before {
+		return
+	}
+
+	n.mu.RLock()
+	handler := n.topologyHandler
+	n.mu.RUnlock()
+
+	if handler != nil {
+		handler(TopologyUpdate{
+			PeerID:    peerID,
+			Version:   n.Topology.GetVersion(),
+			Added:     count - before,
+			NodeCount: count,
+		})
+	}
+}
+
 // RemovePeer removes a peer connection.
 func (n *Node) RemovePeer(peerID string) error {
 	if n.PeerManager == nil {
@@ -203,6 +370,14 @@ func (n *Node) GetPeer(peerID string) (*Peer, bool) {
 	return n.PeerManager.GetPeer(peerID)
 }
 
+// GetPeerInfo returns a copy of a peer's info.
+func (n *Node) GetPeerInfo(peerID string) (PeerInfo, bool) {
+	if n.PeerManager == nil {
+		return PeerInfo{}, false
+	}
+	return n.PeerManager.GetPeerInfo(peerID)
+}
+
 // GetAllPeers returns all connected peers.
 func (n *Node) GetAllPeers() []*Peer {
 	if n.PeerManager == nil {
@@ -211,12 +386,23 @@ func (n *Node) GetAllPeers() []*Peer {
 	return n.PeerManager.GetAllPeers()
 }
 
-// PingPeer sends a ping to a peer.
+// PingPeer sends a ping to a peer and records its protocol version.
+// Incompatible peers trigger the OnVersionMismatch handler.
 func (n *Node) PingPeer(ctx context.Context, peerID string) (*PingResponse, error) {
 	if n.PeerManager == nil {
 		return nil, fmt.Errorf("peer manager not initialized")
 	}
-	return n.PeerManager.PingPeer(ctx, peerID)
+	resp, err := n.PeerManager.PingPeer(ctx, peerID)
+	n.notifyVersionMismatchError(err)
+	return resp, err
+}
+
+// PeerTLS returns the TLS parameters negotiated with a peer on the last successful ping.
+func (n *Node) PeerTLS(peerID string) (TLSConnectionInfo, bool) {
+	if n.PeerManager == nil {
+		return TLSConnectionInfo{}, false
+	}
+	return n.PeerManager.PeerTLS(peerID)
 }
 
 // GetPeerHealth retrieves the health status of a peer.
@@ -262,9 +448,15 @@ func (n *Node) SyncTopology(ctx context.Context, peerID string) error {
 		return fmt.Errorf("peer %s not found", peerID)
 	}
 
+	defer n.notifyTopologyGrowth(peerID, n.Topology.NodeCount())
+
+	version, lineage := n.Topology.versionAndLineage()
 	req := &TopologySyncRequest{
-		SenderId: n.ID,
-		Version:  n.Topology.GetVersion(),
+		SenderId:     n.ID,
+		Version:      version,
+		AcceptDelta:  true,
+		Lineage:      lineage,
+		AcceptStream: true,
 	}
 
 	resp, err := peer.Client.SyncTopology(ctx, req)
@@ -272,6 +464,26 @@ func (n *Node) SyncTopology(ctx context.Context, peerID string) error {
 		return err
 	}
 
+	// The peer's topology is too large for one message; fetch it in batches.
+	if resp.StreamRequired {
+		return n.streamTopology(ctx, peer)
+	}
+
+	if resp.Delta {
+		if resp.Version <= version {
+			return nil
+		}
+		changes := make([]NodeChange, 0, len(resp.Changes))
+		for _, change := range resp.Changes {
+			changes = append(changes, NodeChange{
+				Version: change.Version,
+				Type:    NodeChangeType(change.Type),
+				Node:    protoToNodeInfo(change.Node),
+			})
+		}
+		return n.Topology.applyDelta(resp.Lineage, version, resp.Version, changes, time.Unix(resp.UpdatedAt, 0))
+	}
+
 	if resp.Version > n.Topology.GetVersion() {
 		newTopology := NewTopology()
 		for _, nodeProto := range resp.Nodes {
@@ -279,6 +491,7 @@ func (n *Node) SyncTopology(ctx context.Context, peerID string) error {
 		}
 		newTopology.Version = resp.Version
 		newTopology.UpdatedAt = time.Unix(resp.UpdatedAt, 0)
+		newTopology.lineage = resp.Lineage
 
 		n.Topology.Merge(newTopology)
 	}
@@ -286,31 +499,165 @@ func (n *Node) SyncTopology(ctx context.Context, peerID string) error {
 	return nil
 }
 
-// SyncTopologyWithAllPeers synchronizes topology with all connected peers.
+// SyncTopologyStream synchronizes topology with a peer using the streaming RPC.
+// Batches are staged and merged only once the whole snapshot has arrived, so a
+// failed or inconsistent stream never leaves a partial topology.
+func (n *Node) SyncTopologyStream(ctx context.Context, peerID string) error {
+	if n.Topology == nil || n.PeerManager == nil {
+		return fmt.Errorf("topology or peer manager not initialized")
+	}
+
+	peer, exists := n.GetPeer(peerID)
+	if !exists {
+		return fmt.Errorf("peer %s not found", peerID)
+	}
+
+	defer n.notifyTopologyGrowth(peerID, n.Topology.NodeCount())
+	return n.streamTopology(ctx, peer)
+}
+
+// streamTopology fetches a peer's topology in batches and merges it.
+func (n *Node) streamTopology(ctx context.Context, peer *Peer) error {
+	peerID := peer.Info.ID
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := peer.Client.SyncTopologyStream(ctx, &TopologySyncRequest{
+		SenderId: n.ID,
+		Version:  n.Topology.GetVersion(),
+	})
+	if err != nil {
+		return err
+	}
+
+	staged := NewTopology()
+	var version, updatedAt int64
+	var lineage string
+	received := false
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if !received {
+			version, updatedAt, lineage = chunk.Version, chunk.UpdatedAt, chunk.Lineage
+			received = true
+		} else if chunk.Version != version {
+			return fmt.Errorf("topology stream from peer %s changed version from %d to %d", peerID, version, chunk.Version)
+		}
+
+		for _, nodeProto := range chunk.Nodes {
+			_ = staged.AddNode(protoToNodeInfo(nodeProto))
+		}
+	}
+
+	staged.Version = version
+	staged.UpdatedAt = time.Unix(updatedAt, 0)
+	staged.lineage = lineage
+	n.Topology.Merge(staged)
+	return nil
+}
+
+// SetTopologySyncConcurrency sets how many peers SyncTopologyWithAllPeers syncs
+// at once. Zero or less selects DefaultTopologySyncConcurrency.
+func (n *Node) SetTopologySyncConcurrency(limit int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncConcurrency = limit
+}
+
+// SetTopologySyncTimeout sets how long SyncTopologyWithAllPeers waits for each
+// peer. Zero or less selects DefaultTopologySyncTimeout.
+func (n *Node) SetTopologySyncTimeout(timeout time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncTimeout = timeout
+}
+
+// SyncTopologyWithAllPeers synchronizes topology with all connected peers,
+// syncing up to the configured number of peers concurrently so a slow peer
+// does not hold up the rest. Results are merged as they arrive.
+// Each peer is given at most the configured sync timeout, so the call
+// finishes even if ctx has no deadline.
+// Returns ctx.Err() once ctx is cancelled; peers not yet started are skipped.
 func (n *Node) SyncTopologyWithAllPeers(ctx context.Context) error {
 	if n.Topology == nil || n.PeerManager == nil {
 		return fmt.Errorf("topology or peer manager not initialized")
 	}
 
+	n.mu.RLock()
+	limit := n.syncConcurrency
+	timeout := n.syncTimeout
+	n.mu.RUnlock()
+	if limit <= 0 {
+		limit = DefaultTopologySyncConcurrency
+	}
+	if timeout <= 0 {
+		timeout = DefaultTopologySyncTimeout
+	}
+
 	peers := n.GetAllPeers()
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
 	var lastErr error
 
 	for _, peer := range peers {
-		if err := n.SyncTopology(ctx, peer.Info.ID); err != nil {
-			lastErr = err
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
 		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(peerID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			peerCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := n.SyncTopology(peerCtx, peerID); err != nil {
+				errMu.Lock()
+				lastErr = err
+				errMu.Unlock()
+			}
+		}(peer.Info.ID)
 	}
 
+	// Wait for in-flight syncs; they return promptly once ctx is cancelled.
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return lastErr
 }
 
 // Shutdown gracefully shuts down the node.
+// The server is drained first so in-flight RPCs can finish.
 func (n *Node) Shutdown() error {
+	if n.MeshServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownDrainTimeout)
+		_ = n.MeshServer.Drain(ctx)
+		cancel()
+	}
+
 	n.StopServer()
 
+	var err error
 	if n.PeerManager != nil {
-		return n.PeerManager.Close()
+		err = n.PeerManager.Close()
 	}
 
-	return nil
+	// Closing the peers stops any initial syncs still running.
+	n.initialSyncs.Wait()
+	return err
 }
@@ -2,6 +2,9 @@ package aegis
 
 import (
 	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NodeBuilder provides a fluent interface for creating nodes with required TLS.
@@ -14,6 +17,14 @@ type NodeBuilder struct {
 	registrars   []ServiceRegistrar
 	certDir      string
 	tlsOptions   *TLSOptions
+	tracer       trace.TracerProvider
+	replayWindow time.Duration
+	replayCache  int
+	circuit      *CircuitBreakerConfig
+	retry        *RetryConfig
+	maxMsgSize   int
+	syncLimit    int
+	syncTimeout  time.Duration
 }
 
 // NewNodeBuilder creates a new node builder.
@@ -72,6 +83,56 @@ func (nb *NodeBuilder) WithTLSOptions(opts *TLSOptions) *NodeBuilder {
 	return nb
 }
 
+// WithTracer enables OpenTelemetry tracing of mesh RPCs using the given provider.
+func (nb *NodeBuilder) WithTracer(tp trace.TracerProvider) *NodeBuilder {
+	nb.tracer = tp
+	return nb
+}
+
+// WithReplayProtection makes the node's server reject stale or replayed requests.
+// Zero values select DefaultReplayWindow and DefaultReplayCacheSize.
+func (nb *NodeBuilder) WithReplayProtection(window time.Duration, cacheSize int) *NodeBuilder {
+	nb.replayWindow = window
+	nb.replayCache = cacheSize
+	if window == 0 {
+		nb.replayWindow = DefaultReplayWindow
+	}
+	return nb
+}
+
+// WithCircuitBreaker enables a per-peer circuit breaker on outgoing mesh calls.
+func (nb *NodeBuilder) WithCircuitBreaker(config CircuitBreakerConfig) *NodeBuilder {
+	nb.circuit = &config
+	return nb
+}
+
+// WithRetry retries outgoing unary mesh calls that fail with a transient error.
+func (nb *NodeBuilder) WithRetry(config RetryConfig) *NodeBuilder {
+	nb.retry = &config
+	return nb
+}
+
+// WithMaxMessageSize sets the maximum message size, in bytes, for the node's
+// server and outgoing connections. Defaults to DefaultMaxMessageSize.
+func (nb *NodeBuilder) WithMaxMessageSize(limit int) *NodeBuilder {
+	nb.maxMsgSize = limit
+	return nb
+}
+
+// WithTopologySyncConcurrency sets how many peers SyncTopologyWithAllPeers syncs
+// at once. Defaults to DefaultTopologySyncConcurrency.
+func (nb *NodeBuilder) WithTopologySyncConcurrency(limit int) *NodeBuilder {
+	nb.syncLimit = limit
+	return nb
+}
+
+// WithTopologySyncTimeout sets how long SyncTopologyWithAllPeers waits for each
+// peer. Defaults to DefaultTopologySyncTimeout.
+func (nb *NodeBuilder) WithTopologySyncTimeout(timeout time.Duration) *NodeBuilder {
+	nb.syncTimeout = timeout
+	return nb
+}
+
 // Build creates the node with TLS enabled.
 func (nb *NodeBuilder) Build() (*Node, error) {
 	if nb.id == "" {
@@ -120,6 +181,34 @@ func (nb *NodeBuilder) Build() (*Node, error) {
 	node.MeshServer.SetTLSConfig(tlsConfig)
 	node.PeerManager.SetTLSConfig(tlsConfig)
 
+	if nb.tracer != nil {
+		node.EnableTracing(nb.tracer)
+	}
+
+	if nb.replayWindow > 0 {
+		node.MeshServer.SetReplayProtection(nb.replayWindow, nb.replayCache)
+	}
+
+	if nb.maxMsgSize > 0 {
+		node.SetMaxMessageSize(nb.maxMsgSize)
+	}
+
+	if nb.circuit != nil {
+		node.PeerManager.SetCircuitBreaker(*nb.circuit)
+	}
+
+	if nb.retry != nil {
+		node.PeerManager.SetRetry(*nb.retry)
+	}
+
+	if nb.syncLimit > 0 {
+		node.SetTopologySyncConcurrency(nb.syncLimit)
+	}
+
+	if nb.syncTimeout > 0 {
+		node.SetTopologySyncTimeout(nb.syncTimeout)
+	}
+
 	// Register service registrars
 	for _, r := range nb.registrars {
 		node.MeshServer.RegisterService(r)
@@ -1,8 +1,17 @@
 package aegis
 
 import (
+	"context"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc"
 )
 
 func TestNewNode(t *testing.T) {
@@ -136,3 +145,360 @@ func TestNodeValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeAddPeerAndSyncWithoutTLS(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	err := node.AddPeerAndSync(context.Background(), PeerInfo{ID: "peer-1", Address: "localhost:1", Type: NodeTypeGeneric})
+	if err == nil {
+		t.Error("expected error when adding peer without TLS config")
+	}
+}
+
+func TestNodeAddPeerAndSyncUnreachablePeer(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.PeerManager.SetTLSConfig(&TLSConfig{CertPool: x509.NewCertPool()})
+
+	var syncErr error
+	node.OnInitialSync(func(peerID string, err error) { syncErr = err })
+
+	err := node.AddPeerAndSync(context.Background(), PeerInfo{ID: "peer-1", Address: "localhost:1", Type: NodeTypeGeneric})
+	if err != nil {
+		t.Fatalf("expected add to succeed despite unreachable peer, got %v", err)
+	}
+	node.initialSyncs.Wait()
+
+	if syncErr == nil {
+		t.Error("expected the failed initial sync to be reported")
+	}
+	if _, exists := node.GetPeer("peer-1"); !exists {
+		t.Error("expected peer to remain added after failed sync")
+	}
+	if node.Topology.NodeCount() != 1 {
+		t.Errorf("expected topology to contain only self, got %d nodes", node.Topology.NodeCount())
+	}
+}
+
+func TestNodeAddPeerAndSyncMergesTopology(t *testing.T) {
+	certDir := t.TempDir()
+	seed := startTLSNode(t, certDir, "seed")
+	joiner := startTLSNode(t, certDir, "joiner")
+	addTopologyNodes(t, seed.Topology, 3)
+
+	var updates []TopologyUpdate
+	joiner.OnTopologyChanged(func(update TopologyUpdate) { updates = append(updates, update) })
+	syncErr := errors.New("initial sync not reported")
+	joiner.OnInitialSync(func(peerID string, err error) { syncErr = err })
+
+	if err := joiner.AddPeerAndSync(context.Background(), PeerInfo{ID: seed.ID, Address: seed.Address}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	joiner.initialSyncs.Wait()
+
+	if syncErr != nil {
+		t.Errorf("expected initial sync to succeed, got %v", syncErr)
+	}
+	for _, id := range []string{"seed", "node-0", "node-1", "node-2"} {
+		if _, exists := joiner.Topology.GetNode(id); !exists {
+			t.Errorf("expected %s in topology after initial sync", id)
+		}
+	}
+	// The seed's newer topology replaces the joiner's single-node one.
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 topology changed event, got %d", len(updates))
+	}
+	if updates[0].PeerID != seed.ID || updates[0].Added != 3 || updates[0].NodeCount != 4 {
+		t.Errorf("unexpected topology update: %+v", updates[0])
+	}
+
+	// A sync that brings nothing new does not fire the event.
+	if err := joiner.SyncTopology(context.Background(), seed.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Errorf("expected no event for an unchanged topology, got %d events", len(updates))
+	}
+}
+
+func TestNodeAddPeerAndSyncStopsWhenPeerRemoved(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.PeerManager.SetTLSConfig(&TLSConfig{CertPool: x509.NewCertPool()})
+
+	// A listener that never completes the handshake keeps the sync waiting.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	if err := node.AddPeerAndSync(context.Background(), PeerInfo{ID: "peer-1", Address: listener.Addr().String()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := node.RemovePeer("peer-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		node.initialSyncs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("initial sync still running after peer was removed")
+	}
+}
+
+func addTopologyNodes(t *testing.T, topology *Topology, count int) {
+	t.Helper()
+	for i := range count {
+		id := fmt.Sprintf("node-%d", i)
+		if err := topology.AddNode(NodeInfo{ID: id, Name: id, Type: NodeTypeGeneric, Address: fmt.Sprintf("localhost:%d", 10000+i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSyncTopologyStreamBatches(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	addTopologyNodes(t, node.Topology, 2*topologyStreamBatchSize)
+
+	collector := &chunkCollector{}
+	if err := node.MeshServer.SyncTopologyStream(&TopologySyncRequest{SenderId: "peer"}, collector); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(collector.chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(collector.chunks))
+	}
+
+	total := 0
+	for _, chunk := range collector.chunks {
+		if chunk.Version != node.Topology.GetVersion() {
+			t.Errorf("expected chunk version %d, got %d", node.Topology.GetVersion(), chunk.Version)
+		}
+		total += len(chunk.Nodes)
+	}
+	if total != node.Topology.NodeCount() {
+		t.Errorf("expected %d nodes streamed, got %d", node.Topology.NodeCount(), total)
+	}
+}
+
+func TestSyncTopologyStreamUpToDate(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	collector := &chunkCollector{}
+	req := &TopologySyncRequest{SenderId: "peer", Version: node.Topology.GetVersion()}
+	if err := node.MeshServer.SyncTopologyStream(req, collector); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(collector.chunks) != 1 || len(collector.chunks[0].Nodes) != 0 {
+		t.Errorf("expected a single empty chunk, got %v", collector.chunks)
+	}
+}
+
+func TestNodeSyncTopologyStream(t *testing.T) {
+	local := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	remote := NewNode("remote", "Remote", NodeTypeGeneric, "localhost:9002")
+	addTopologyNodes(t, remote.Topology, topologyStreamBatchSize+10)
+	connectLoopback(local, remote)
+
+	if err := local.SyncTopologyStream(context.Background(), remote.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if local.Topology.GetVersion() != remote.Topology.GetVersion() {
+		t.Errorf("expected version %d, got %d", remote.Topology.GetVersion(), local.Topology.GetVersion())
+	}
+	if local.Topology.NodeCount() != remote.Topology.NodeCount() {
+		t.Errorf("expected %d nodes, got %d", remote.Topology.NodeCount(), local.Topology.NodeCount())
+	}
+}
+
+func TestNodeSyncTopologyJoinsLargeMeshByStreaming(t *testing.T) {
+	local := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	remote := NewNode("remote", "Remote", NodeTypeGeneric, "localhost:9002")
+	addTopologyNodes(t, remote.Topology, topologyStreamThreshold)
+	connectLoopback(local, remote)
+
+	resp, err := remote.MeshServer.SyncTopology(context.Background(), &TopologySyncRequest{SenderId: "local", AcceptStream: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.StreamRequired || len(resp.Nodes) != 0 {
+		t.Fatalf("expected large topology to require streaming, got stream_required=%v nodes=%d", resp.StreamRequired, len(resp.Nodes))
+	}
+
+	// The joining node's own topology is small; the peer's size decides.
+	if err := local.SyncTopology(context.Background(), remote.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if local.Topology.NodeCount() != remote.Topology.NodeCount() {
+		t.Errorf("expected %d nodes, got %d", remote.Topology.NodeCount(), local.Topology.NodeCount())
+	}
+}
+
+func TestSyncTopologyLargeWithoutStreamSupport(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	addTopologyNodes(t, node.Topology, topologyStreamThreshold)
+
+	resp, err := node.MeshServer.SyncTopology(context.Background(), &TopologySyncRequest{SenderId: "peer"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StreamRequired || len(resp.Nodes) != node.Topology.NodeCount() {
+		t.Errorf("expected full snapshot for a requester that cannot stream, got stream_required=%v nodes=%d", resp.StreamRequired, len(resp.Nodes))
+	}
+}
+
+// tornStreamClient returns chunks whose versions differ mid-stream.
+type tornStreamClient struct {
+	MeshServiceClient
+}
+
+func (c *tornStreamClient) SyncTopologyStream(ctx context.Context, req *TopologySyncRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[TopologySyncChunk], error) {
+	return &chunkReplayer{chunks: []*TopologySyncChunk{
+		{Version: 10, Nodes: []*TopologyNode{{Id: "a"}}},
+		{Version: 11, Nodes: []*TopologyNode{{Id: "b"}}},
+	}}, nil
+}
+
+func TestNodeSyncTopologyStreamRejectsTornSnapshot(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.PeerManager.peers["peer"] = &Peer{
+		Info:   PeerInfo{ID: "peer"},
+		Client: &tornStreamClient{},
+	}
+	version := node.Topology.GetVersion()
+
+	if err := node.SyncTopologyStream(context.Background(), "peer"); err == nil {
+		t.Fatal("expected error for stream with changing version")
+	}
+
+	if node.Topology.GetVersion() != version || node.Topology.NodeCount() != 1 {
+		t.Error("expected topology to be unchanged after torn stream")
+	}
+}
+
+func TestNodeSyncTopologyDelta(t *testing.T) {
+	local := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	remote := NewNode("remote", "Remote", NodeTypeGeneric, "localhost:9002")
+	addTopologyNodes(t, remote.Topology, 5)
+	connectLoopback(local, remote)
+
+	if err := local.SyncTopology(context.Background(), remote.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	version := local.Topology.GetVersion()
+
+	if err := remote.Topology.RemoveNode("node-0"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := remote.MeshServer.SyncTopology(context.Background(), &TopologySyncRequest{
+		Version:     version,
+		AcceptDelta: true,
+		Lineage:     local.Topology.lineage,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Delta || len(resp.Changes) != 1 {
+		t.Fatalf("expected a delta with one change, got delta=%v changes=%d", resp.Delta, len(resp.Changes))
+	}
+
+	if err := local.SyncTopology(context.Background(), remote.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if local.Topology.GetVersion() != remote.Topology.GetVersion() {
+		t.Errorf("expected version %d, got %d", remote.Topology.GetVersion(), local.Topology.GetVersion())
+	}
+	if _, exists := local.Topology.GetNode("node-0"); exists {
+		t.Error("expected removed node to be applied from delta")
+	}
+}
+
+func TestSyncTopologyWithAllPeersCancelled(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.SetTopologySyncConcurrency(1)
+	calls := addBlockingPeers(node, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := node.SyncTopologyWithAllPeers(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected prompt return, took %v", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected remaining peers to be skipped, got %d calls", n)
+	}
+}
+
+func TestSyncTopologyWithAllPeersTimesOutSlowPeers(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.SetTopologySyncTimeout(20 * time.Millisecond)
+	calls := addBlockingPeers(node, 3)
+
+	start := time.Now()
+	err := node.SyncTopologyWithAllPeers(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected slow peers to time out promptly, took %v", elapsed)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("expected every peer to be tried, got %d calls", n)
+	}
+}
+
+// slowSyncClient answers SyncTopology after a delay, tracking peak concurrency.
+type slowSyncClient struct {
+	MeshServiceClient
+	active *atomic.Int32
+	peak   *atomic.Int32
+}
+
+func (c *slowSyncClient) SyncTopology(ctx context.Context, _ *TopologySyncRequest, _ ...grpc.CallOption) (*TopologySyncResponse, error) {
+	active := c.active.Add(1)
+	defer c.active.Add(-1)
+	for {
+		peak := c.peak.Load()
+		if active <= peak || c.peak.CompareAndSwap(peak, active) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return &TopologySyncResponse{Delta: true}, nil
+}
+
+func TestSyncTopologyWithAllPeersConcurrencyLimit(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	node.SetTopologySyncConcurrency(3)
+
+	active, peak := &atomic.Int32{}, &atomic.Int32{}
+	for i := range 9 {
+		id := fmt.Sprintf("peer-%d", i)
+		node.PeerManager.peers[id] = &Peer{
+			Info:   PeerInfo{ID: id},
+			Client: &slowSyncClient{active: active, peak: peak},
+		}
+	}
+
+	if err := node.SyncTopologyWithAllPeers(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p := peak.Load(); p > 3 {
+		t.Errorf("expected at most 3 concurrent syncs, got %d", p)
+	} else if p < 2 {
+		t.Errorf("expected syncs to overlap, got peak of %d", p)
+	}
+}
@@ -6,8 +6,20 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
+	grpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxConcurrentPeerDials bounds the number of connections AddPeers sets up at once.
+	maxConcurrentPeerDials = 8
+	// peerConnectTimeout bounds how long AddPeers waits for each peer to become reachable.
+	peerConnectTimeout = 10 * time.Second
 )
 
 // PeerInfo contains information about a peer node.
@@ -15,13 +27,35 @@ type PeerInfo struct {
 	ID      string   `json:"id"`
 	Address string   `json:"address"`
 	Type    NodeType `json:"type"`
+	Version int      `json:"version,omitempty"`
 }
 
 // Peer represents a connected peer node.
+// Info.Version is updated under the manager's lock when the peer is pinged;
+// use GetPeerInfo to read a consistent copy of Info.
 type Peer struct {
 	Info   PeerInfo
 	Client MeshServiceClient
 	Conn   *grpc.ClientConn
+
+	// ctx is cancelled when the peer is removed, stopping any watchers.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	breaker *circuitBreaker
+
+	// latency records the duration of unary calls to the peer.
+	latency *latencyHistogram
+
+	// tlsInfo is the TLS state negotiated on the last successful ping.
+	tlsInfo *TLSConnectionInfo
+}
+
+// PeerStateChange describes a connection state transition for a peer.
+type PeerStateChange struct {
+	PeerID string
+	From   connectivity.State
+	To     connectivity.State
 }
 
 // PeerManager manages connections to peer nodes.
@@ -30,13 +64,20 @@ type PeerManager struct {
 	peers     map[string]*Peer
 	tlsConfig *TLSConfig
 	mu        sync.RWMutex
+
+	tracerProvider trace.TracerProvider
+	circuitConfig  *CircuitBreakerConfig
+	retryConfig    *RetryConfig
+	maxMessageSize int
+	capabilities   map[string]Capabilities
 }
 
 // NewPeerManager creates a new peer manager.
 func NewPeerManager(nodeID string) *PeerManager {
 	return &PeerManager{
-		nodeID: nodeID,
-		peers:  make(map[string]*Peer),
+		nodeID:       nodeID,
+		peers:        make(map[string]*Peer),
+		capabilities: make(map[string]Capabilities),
 	}
 }
 
@@ -47,37 +88,207 @@ func (pm *PeerManager) SetTLSConfig(tlsConfig *TLSConfig) {
 	pm.tlsConfig = tlsConfig
 }
 
-// AddPeer adds a new peer connection.
-func (pm *PeerManager) AddPeer(info PeerInfo) error {
+// SetTracerProvider enables trace context propagation on peer connections.
+// Applies to peers added after the call.
+func (pm *PeerManager) SetTracerProvider(tp trace.TracerProvider) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
+	pm.tracerProvider = tp
+}
 
-	if _, exists := pm.peers[info.ID]; exists {
+// dialOptions returns the dial options for a connection to the given peer.
+// Callers must hold the lock.
+func (pm *PeerManager) dialOptions(peerID string) []grpc.DialOption {
+	creds := credentials.NewTLS(pm.tlsConfig.GetClientTLSConfig(peerID))
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	opts = append(opts, interceptorDialOptions(pm.tracerProvider)...)
+	opts = append(opts, messageSizeDialOptions(pm.maxMessageSize)...)
+	return opts
+}
+
+// interceptorDialOptions returns the client interceptor chain shared by all mesh connections.
+func interceptorDialOptions(tp trace.TracerProvider) []grpc.DialOption {
+	unary := []grpc.UnaryClientInterceptor{requestIDUnaryClientInterceptor}
+	stream := []grpc.StreamClientInterceptor{requestIDStreamClientInterceptor}
+
+	if tp != nil {
+		unary = append(unary, tracingUnaryClientInterceptor(tp))
+		stream = append(stream, tracingStreamClientInterceptor(tp))
+	}
+
+	unary = append(unary, nonceUnaryClientInterceptor)
+	stream = append(stream, nonceStreamClientInterceptor)
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	}
+}
+
+// AddPeer adds a new peer connection.
+// The connection is established lazily on first use, so only configuration
+// errors are reported; use AddPeers to also wait until the peer is reachable.
+func (pm *PeerManager) AddPeer(info PeerInfo) error {
+	pm.mu.RLock()
+	_, exists := pm.peers[info.ID]
+	tlsConfig := pm.tlsConfig
+	var opts []grpc.DialOption
+	var breaker *circuitBreaker
+	var latency *latencyHistogram
+	if !exists && tlsConfig != nil {
+		// Retries wrap the rest of the chain, so they are installed first.
+		if pm.retryConfig != nil {
+			opts = append(opts, grpc.WithChainUnaryInterceptor(retryUnaryInterceptor(*pm.retryConfig)))
+		}
+		// Latency is recorded per attempt.
+		latency = newLatencyHistogram()
+		opts = append(opts, grpc.WithChainUnaryInterceptor(latency.unaryInterceptor))
+		opts = append(opts, pm.dialOptions(info.ID)...)
+		if pm.circuitConfig != nil {
+			breaker = newCircuitBreaker(info.ID, *pm.circuitConfig)
+		}
+	}
+	pm.mu.RUnlock()
+
+	if exists {
 		return fmt.Errorf("peer %s already exists", info.ID)
 	}
 
-	if pm.tlsConfig == nil {
+	if tlsConfig == nil {
 		return fmt.Errorf("TLS configuration is required but not set")
 	}
 
-	creds := credentials.NewTLS(pm.tlsConfig.GetClientTLSConfig(info.ID))
-	conn, err := grpc.NewClient(info.Address, grpc.WithTransportCredentials(creds))
+	if breaker != nil {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(breaker.unaryInterceptor),
+			grpc.WithChainStreamInterceptor(breaker.streamInterceptor),
+		)
+	}
+
+	conn, err := grpc.NewClient(info.Address, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to connect to peer %s at %s: %w", info.ID, info.Address, err)
 	}
 
 	client := NewMeshServiceClient(conn)
+	ctx, cancel := context.WithCancel(context.Background())
 
 	peer := &Peer{
-		Info:   info,
-		Client: client,
-		Conn:   conn,
+		Info:    info,
+		Client:  client,
+		Conn:    conn,
+		ctx:     ctx,
+		cancel:  cancel,
+		breaker: breaker,
+		latency: latency,
 	}
 
+	pm.mu.Lock()
+	if _, exists := pm.peers[info.ID]; exists {
+		pm.mu.Unlock()
+		cancel()
+		_ = conn.Close()
+		return fmt.Errorf("peer %s already exists", info.ID)
+	}
 	pm.peers[info.ID] = peer
+	pm.mu.Unlock()
+
+	go pm.invalidateCapabilitiesOnDisconnect(peer)
 	return nil
 }
 
+// AddPeers adds multiple peers concurrently and waits for each connection to
+// become ready, returning the result for each peer ID. A nil entry means the
+// peer was added, is reachable and answered a ping, which records its version. A peer that cannot be reached before ctx is
+// done or peerConnectTimeout elapses is removed again. IDs listed more than once
+// are rejected without being added. Failures do not roll back successful adds.
+func (pm *PeerManager) AddPeers(ctx context.Context, infos []PeerInfo) map[string]error {
+	results := make(map[string]error, len(infos))
+	listed := make(map[string]int, len(infos))
+	for _, info := range infos {
+		listed[info.ID]++
+	}
+
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentPeerDials)
+
+	for _, info := range infos {
+		if listed[info.ID] > 1 {
+			resultsMu.Lock()
+			results[info.ID] = fmt.Errorf("peer %s listed more than once", info.ID)
+			resultsMu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(info PeerInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := pm.connectPeer(ctx, info)
+
+			resultsMu.Lock()
+			results[info.ID] = err
+			resultsMu.Unlock()
+		}(info)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// connectPeer adds a peer and waits until its connection is ready.
+// The peer is removed again if it cannot be reached.
+func (pm *PeerManager) connectPeer(ctx context.Context, info PeerInfo) error {
+	if err := pm.AddPeer(info); err != nil {
+		return err
+	}
+
+	peer, exists := pm.GetPeer(info.ID)
+	if !exists {
+		return fmt.Errorf("peer %s not found", info.ID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, peerConnectTimeout)
+	defer cancel()
+
+	if err := waitForReady(ctx, peer.Conn); err != nil {
+		_ = pm.RemovePeer(info.ID)
+		return fmt.Errorf("peer %s at %s is unreachable: %w", info.ID, info.Address, err)
+	}
+
+	// Negotiate versions so incompatible peers are not kept and the
+	// peer's version is recorded.
+	if _, err := pm.PingPeer(ctx, info.ID); err != nil {
+		_ = pm.RemovePeer(info.ID)
+		return fmt.Errorf("peer %s at %s failed to respond: %w", info.ID, info.Address, err)
+	}
+
+	return nil
+}
+
+// waitForReady starts connecting and blocks until the connection is READY,
+// the attempt fails, or ctx is done.
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) error {
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure:
+			return fmt.Errorf("connection failed")
+		case connectivity.Shutdown:
+			return fmt.Errorf("connection closed")
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return ctx.Err()
+		}
+	}
+}
+
 // RemovePeer removes a peer connection.
 func (pm *PeerManager) RemovePeer(peerID string) error {
 	pm.mu.Lock()
@@ -88,11 +299,16 @@ func (pm *PeerManager) RemovePeer(peerID string) error {
 		return fmt.Errorf("peer %s not found", peerID)
 	}
 
+	if peer.cancel != nil {
+		peer.cancel()
+	}
+
 	if err := peer.Conn.Close(); err != nil {
 		return fmt.Errorf("failed to close connection to peer %s: %w", peerID, err)
 	}
 
 	delete(pm.peers, peerID)
+	delete(pm.capabilities, peerID)
 	return nil
 }
 
@@ -105,6 +321,19 @@ func (pm *PeerManager) GetPeer(peerID string) (*Peer, bool) {
 	return peer, exists
 }
 
+// GetPeerInfo returns a copy of a peer's info, including the protocol
+// version recorded by the last successful ping.
+func (pm *PeerManager) GetPeerInfo(peerID string) (PeerInfo, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	peer, exists := pm.peers[peerID]
+	if !exists {
+		return PeerInfo{}, false
+	}
+	return peer.Info, true
+}
+
 // GetAllPeers returns all connected peers.
 func (pm *PeerManager) GetAllPeers() []*Peer {
 	pm.mu.RLock()
@@ -131,7 +360,8 @@ func (pm *PeerManager) GetPeersByType(nodeType NodeType) []*Peer {
 	return peers
 }
 
-// PingPeer sends a ping request to a peer.
+// PingPeer sends a ping request to a peer and records the peer's protocol version.
+// Returns ErrVersionMismatch if the peer's version is not supported.
 func (pm *PeerManager) PingPeer(ctx context.Context, peerID string) (*PingResponse, error) {
 	peer, exists := pm.GetPeer(peerID)
 	if !exists {
@@ -139,11 +369,38 @@ func (pm *PeerManager) PingPeer(ctx context.Context, peerID string) (*PingRespon
 	}
 
 	req := &PingRequest{
-		SenderId:  pm.nodeID,
-		Timestamp: time.Now().Unix(),
+		SenderId:        pm.nodeID,
+		Timestamp:       time.Now().Unix(),
+		ProtocolVersion: ProtocolVersion,
+	}
+
+	var remote grpcpeer.Peer
+	resp, err := peer.Client.Ping(ctx, req, grpc.Peer(&remote))
+	if status.Code(err) == codes.FailedPrecondition {
+		// The peer rejected this node's protocol version.
+		return nil, &versionMismatchError{mismatch: VersionMismatch{
+			PeerID:       peerID,
+			LocalVersion: ProtocolVersion,
+			Reason:       status.Convert(err).Message(),
+		}}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return peer.Client.Ping(ctx, req)
+	pm.mu.Lock()
+	peer.Info.Version = int(resp.ProtocolVersion)
+	if tlsInfo, ok := remote.AuthInfo.(credentials.TLSInfo); ok {
+		info := tlsConnectionInfo(tlsInfo)
+		peer.tlsInfo = &info
+	}
+	pm.mu.Unlock()
+
+	if mismatch := checkPeerVersion(peerID, int(resp.ProtocolVersion), MinProtocolVersion); mismatch != nil {
+		return resp, &versionMismatchError{mismatch: *mismatch}
+	}
+
+	return resp, nil
 }
 
 // GetPeerHealth retrieves the health status of a peer.
@@ -196,12 +453,16 @@ func (pm *PeerManager) Close() error {
 
 	var lastErr error
 	for _, peer := range pm.peers {
+		if peer.cancel != nil {
+			peer.cancel()
+		}
 		if err := peer.Conn.Close(); err != nil {
 			lastErr = err
 		}
 	}
 
 	pm.peers = make(map[string]*Peer)
+	pm.capabilities = make(map[string]Capabilities)
 	return lastErr
 }
 
@@ -222,5 +483,50 @@ func (pm *PeerManager) IsConnected(peerID string) bool {
 		return false
 	}
 
-	return peer.Conn.GetState().String() == "READY"
+	return peer.Conn.GetState() == connectivity.Ready
+}
+
+// PeerTLS returns the TLS parameters negotiated with a peer, as observed on
+// the last successful ping. Returns false if none have been recorded.
+func (pm *PeerManager) PeerTLS(peerID string) (TLSConnectionInfo, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	peer, exists := pm.peers[peerID]
+	if !exists || peer.tlsInfo == nil {
+		return TLSConnectionInfo{}, false
+	}
+	return *peer.tlsInfo, true
+}
+
+// WatchState streams connection state transitions for a peer.
+// The channel is closed when the peer is removed or the manager is closed.
+func (pm *PeerManager) WatchState(peerID string) (<-chan PeerStateChange, error) {
+	peer, exists := pm.GetPeer(peerID)
+	if !exists {
+		return nil, fmt.Errorf("peer %s not found", peerID)
+	}
+
+	if peer.Conn == nil || peer.ctx == nil {
+		return nil, fmt.Errorf("peer %s has no connection to watch", peerID)
+	}
+
+	changes := make(chan PeerStateChange, 1)
+
+	go func() {
+		defer close(changes)
+
+		state := peer.Conn.GetState()
+		for peer.Conn.WaitForStateChange(peer.ctx, state) {
+			next := peer.Conn.GetState()
+			select {
+			case changes <- PeerStateChange{PeerID: peerID, From: state, To: next}:
+			case <-peer.ctx.Done():
+				return
+			}
+			state = next
+		}
+	}()
+
+	return changes, nil
 }
@@ -2,7 +2,11 @@ package aegis
 
 import (
 	"context"
+	"crypto/x509"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
 )
 
 func TestNewPeerManager(t *testing.T) {
@@ -148,3 +152,138 @@ func TestPeerManagerSyncTopologyNonexistentPeer(t *testing.T) {
 		t.Error("expected error when syncing topology with nonexistent peer")
 	}
 }
+
+func TestPeerManagerWatchStateNonexistentPeer(t *testing.T) {
+	pm := NewPeerManager("test-node")
+
+	_, err := pm.WatchState("nonexistent")
+	if err == nil {
+		t.Error("expected error when watching nonexistent peer")
+	}
+}
+
+func TestPeerManagerWatchState(t *testing.T) {
+	pm := NewPeerManager("test-node")
+	pm.SetTLSConfig(&TLSConfig{CertPool: x509.NewCertPool()})
+
+	err := pm.AddPeer(PeerInfo{ID: "peer-1", Address: "localhost:1", Type: NodeTypeGeneric})
+	if err != nil {
+		t.Fatalf("failed to add peer: %v", err)
+	}
+
+	changes, err := pm.WatchState("peer-1")
+	if err != nil {
+		t.Fatalf("failed to watch peer: %v", err)
+	}
+
+	peer, _ := pm.GetPeer("peer-1")
+	peer.Conn.Connect()
+
+	select {
+	case change := <-changes:
+		if change.PeerID != "peer-1" {
+			t.Errorf("expected peer ID 'peer-1', got '%s'", change.PeerID)
+		}
+		if change.From != connectivity.Idle {
+			t.Errorf("expected transition from IDLE, got %s", change.From)
+		}
+		if change.From == change.To {
+			t.Errorf("expected a state change, got %s -> %s", change.From, change.To)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for state change")
+	}
+
+	if err := pm.RemovePeer("peer-1"); err != nil {
+		t.Fatalf("failed to remove peer: %v", err)
+	}
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("watch channel not closed after peer removal")
+		}
+	}
+}
+
+func TestPeerManagerAddPeersPartialFailure(t *testing.T) {
+	pm := NewPeerManager("test-node")
+	pm.SetTLSConfig(&TLSConfig{CertPool: x509.NewCertPool()})
+
+	if err := pm.AddPeer(PeerInfo{ID: "existing", Address: "localhost:1"}); err != nil {
+		t.Fatalf("failed to add peer: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := pm.AddPeers(ctx, []PeerInfo{
+		{ID: "peer-1", Address: "127.0.0.1:1", Type: NodeTypeGeneric},
+		{ID: "existing", Address: "localhost:1", Type: NodeTypeGeneric},
+		{ID: "peer-2", Address: "127.0.0.1:1", Type: NodeTypeGeneric},
+	})
+	defer pm.Close()
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results["peer-1"] == nil || results["peer-2"] == nil {
+		t.Errorf("expected unreachable peers to fail, got %v", results)
+	}
+	if results["existing"] == nil {
+		t.Error("expected error for duplicate peer")
+	}
+	if pm.Count() != 1 {
+		t.Errorf("expected unreachable peers to be removed, got %d peers", pm.Count())
+	}
+}
+
+func TestPeerManagerAddPeersReachable(t *testing.T) {
+	certDir := t.TempDir()
+	server := startTLSNode(t, certDir, "server")
+
+	client := NewNode("client", "Client", NodeTypeGeneric, "127.0.0.1:0")
+	if err := client.EnableTLS(certDir); err != nil {
+		t.Fatalf("failed to enable TLS: %v", err)
+	}
+	defer client.PeerManager.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := client.AddPeers(ctx, []PeerInfo{
+		{ID: "server", Address: server.Address},
+		{ID: "twice", Address: server.Address},
+		{ID: "twice", Address: server.Address},
+	})
+
+	if results["server"] != nil {
+		t.Errorf("expected reachable peer to be added, got %v", results["server"])
+	}
+	if results["twice"] == nil {
+		t.Error("expected error for peer ID listed twice")
+	}
+	if client.PeerManager.Count() != 1 {
+		t.Errorf("expected 1 peer, got %d", client.PeerManager.Count())
+	}
+	if !client.PeerManager.IsConnected("server") {
+		t.Error("expected peer connection to be ready")
+	}
+	if info, _ := client.GetPeerInfo("server"); info.Version != ProtocolVersion {
+		t.Errorf("expected peer version %d to be recorded on connect, got %d", ProtocolVersion, info.Version)
+	}
+}
+
+func TestPeerManagerAddPeersWithoutTLS(t *testing.T) {
+	pm := NewPeerManager("test-node")
+
+	results := pm.AddPeers(context.Background(), []PeerInfo{{ID: "peer-1", Address: "localhost:2"}})
+	if results["peer-1"] == nil {
+		t.Error("expected error when adding peers without TLS config")
+	}
+}
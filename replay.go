@@ -0,0 +1,194 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys carrying replay-protection values on every mesh call.
+const (
+	nonceMetadataKey     = "x-aegis-nonce"
+	timestampMetadataKey = "x-aegis-timestamp"
+)
+
+const (
+	// DefaultReplayWindow is the default allowed clock difference for request timestamps.
+	DefaultReplayWindow = 30 * time.Second
+	// DefaultReplayCacheSize is the default maximum number of live nonces remembered by the server.
+	DefaultReplayCacheSize = 65536
+)
+
+var (
+	// ErrMissingNonce is returned when a request lacks replay-protection metadata.
+	ErrMissingNonce = errors.New("request missing nonce or timestamp")
+	// ErrStaleRequest is returned when a request timestamp is outside the freshness window.
+	ErrStaleRequest = errors.New("request timestamp outside freshness window")
+	// ErrReplayedRequest is returned when a request nonce has already been seen.
+	ErrReplayedRequest = errors.New("request nonce already used")
+	// ErrReplayCacheFull is returned when the server holds too many live nonces to accept more requests.
+	ErrReplayCacheFull = errors.New("replay cache full")
+)
+
+// replayGuard rejects stale and repeated requests.
+//
+// The nonce and timestamp travel as call metadata and are not bound to the
+// payload. Mesh connections use mutual TLS, which already protects each call's
+// metadata and payload together against tampering and replay on the wire; the
+// guard stops a request from being accepted twice, e.g. when resent by a peer.
+type replayGuard struct {
+	window time.Duration
+	nonces *nonceCache
+}
+
+// newReplayGuard creates a guard with the given freshness window and nonce cache size.
+// The cache must hold as many requests as the server receives per window;
+// requests beyond that are rejected until older nonces expire.
+func newReplayGuard(window time.Duration, cacheSize int) *replayGuard {
+	if window <= 0 {
+		window = DefaultReplayWindow
+	}
+	if cacheSize <= 0 {
+		cacheSize = DefaultReplayCacheSize
+	}
+	return &replayGuard{
+		window: window,
+		nonces: newNonceCache(cacheSize),
+	}
+}
+
+// nonceEntry is a nonce and the time after which it can no longer be accepted.
+type nonceEntry struct {
+	nonce   string
+	expires time.Time
+}
+
+// nonceCache remembers nonces until their request timestamp leaves the
+// freshness window. Nonces are never evicted while they could still be
+// accepted, so the cache rejects new nonces when it is full.
+type nonceCache struct {
+	expires map[string]time.Time
+	order   []nonceEntry
+	size    int
+	mu      sync.Mutex
+}
+
+func newNonceCache(size int) *nonceCache {
+	return &nonceCache{
+		expires: make(map[string]time.Time),
+		size:    size,
+	}
+}
+
+// Add records a nonce that may be replayed until expires.
+// It returns ErrReplayedRequest if the nonce is still remembered and
+// ErrReplayCacheFull if there is no room for it.
+func (c *nonceCache) Add(nonce string, expires, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune(now)
+
+	if _, seen := c.expires[nonce]; seen {
+		return ErrReplayedRequest
+	}
+	if len(c.expires) >= c.size {
+		return ErrReplayCacheFull
+	}
+
+	c.expires[nonce] = expires
+	c.order = append(c.order, nonceEntry{nonce: nonce, expires: expires})
+	return nil
+}
+
+// prune forgets expired nonces, oldest first. Callers must hold the lock.
+// Entries are in arrival order, so one that expires later than its successors
+// holds them until it expires; they are still pruned within one window.
+func (c *nonceCache) prune(now time.Time) {
+	i := 0
+	for i < len(c.order) && !now.Before(c.order[i].expires) {
+		delete(c.expires, c.order[i].nonce)
+		i++
+	}
+	c.order = c.order[i:]
+}
+
+// Len returns the number of remembered nonces.
+func (c *nonceCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.expires)
+}
+
+// check validates the nonce and timestamp in the incoming metadata.
+func (g *replayGuard) check(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	nonces := md.Get(nonceMetadataKey)
+	timestamps := md.Get(timestampMetadataKey)
+	if len(nonces) == 0 || len(timestamps) == 0 || nonces[0] == "" {
+		return status.Error(codes.Unauthenticated, ErrMissingNonce.Error())
+	}
+
+	sent, err := strconv.ParseInt(timestamps[0], 10, 64)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, ErrMissingNonce.Error())
+	}
+
+	now := time.Now()
+	sentAt := time.Unix(0, sent)
+	age := now.Sub(sentAt)
+	if age > g.window || age < -g.window {
+		return status.Error(codes.Unauthenticated, ErrStaleRequest.Error())
+	}
+
+	// Once sentAt+window has passed the request is stale, so its nonce can be forgotten.
+	switch err := g.nonces.Add(nonces[0], sentAt.Add(g.window), now); {
+	case errors.Is(err, ErrReplayedRequest):
+		return status.Error(codes.Unauthenticated, ErrReplayedRequest.Error())
+	case errors.Is(err, ErrReplayCacheFull):
+		return status.Error(codes.Unavailable, ErrReplayCacheFull.Error())
+	}
+
+	return nil
+}
+
+// unaryInterceptor rejects stale or replayed unary calls.
+func (g *replayGuard) unaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := g.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor rejects stale or replayed streams.
+func (g *replayGuard) streamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := g.check(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// withNonce attaches a fresh nonce and timestamp to the outgoing metadata.
+func withNonce(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx,
+		nonceMetadataKey, NewID(),
+		timestampMetadataKey, strconv.FormatInt(time.Now().UnixNano(), 10),
+	)
+}
+
+// nonceUnaryClientInterceptor stamps each unary call with a nonce and timestamp.
+func nonceUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withNonce(ctx), method, req, reply, cc, opts...)
+}
+
+// nonceStreamClientInterceptor stamps each stream with a nonce and timestamp.
+func nonceStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withNonce(ctx), desc, cc, method, opts...)
+}
@@ -0,0 +1,130 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// stampedContext returns an incoming context carrying the given nonce and timestamp.
+func stampedContext(nonce string, sent time.Time) context.Context {
+	md := metadata.Pairs(
+		nonceMetadataKey, nonce,
+		timestampMetadataKey, strconv.FormatInt(sent.UnixNano(), 10),
+	)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestReplayGuardAcceptsFreshRequest(t *testing.T) {
+	guard := newReplayGuard(time.Minute, 16)
+
+	if err := guard.check(stampedContext("nonce-1", time.Now())); err != nil {
+		t.Errorf("expected fresh request to be accepted, got %v", err)
+	}
+}
+
+func TestReplayGuardRejectsReplay(t *testing.T) {
+	guard := newReplayGuard(time.Minute, 16)
+	ctx := stampedContext("nonce-1", time.Now())
+
+	if err := guard.check(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := guard.check(ctx)
+	if status.Code(err) != codes.Unauthenticated || status.Convert(err).Message() != ErrReplayedRequest.Error() {
+		t.Errorf("expected replayed request error, got %v", err)
+	}
+}
+
+func TestReplayGuardRejectsStaleRequest(t *testing.T) {
+	guard := newReplayGuard(time.Minute, 16)
+
+	for _, sent := range []time.Time{time.Now().Add(-2 * time.Minute), time.Now().Add(2 * time.Minute)} {
+		err := guard.check(stampedContext("nonce-"+sent.String(), sent))
+		if status.Convert(err).Message() != ErrStaleRequest.Error() {
+			t.Errorf("expected stale request error for %v, got %v", sent, err)
+		}
+	}
+}
+
+func TestReplayGuardRejectsMissingMetadata(t *testing.T) {
+	guard := newReplayGuard(time.Minute, 16)
+
+	err := guard.check(context.Background())
+	if status.Convert(err).Message() != ErrMissingNonce.Error() {
+		t.Errorf("expected missing nonce error, got %v", err)
+	}
+}
+
+func TestNonceClientInterceptorStampsCalls(t *testing.T) {
+	guard := newReplayGuard(time.Minute, 16)
+
+	var seen []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		seen = append(seen, md.Get(nonceMetadataKey)...)
+		return guard.check(metadata.NewIncomingContext(ctx, md))
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := nonceUnaryClientInterceptor(context.Background(), "/aegis.MeshService/Ping", nil, nil, nil, invoker); err != nil {
+			t.Fatalf("call %d rejected: %v", i, err)
+		}
+	}
+
+	if len(seen) != 2 || seen[0] == seen[1] {
+		t.Errorf("expected two distinct nonces, got %v", seen)
+	}
+}
+
+func TestReplayGuardRemembersNonceForWholeWindow(t *testing.T) {
+	guard := newReplayGuard(time.Minute, 2)
+
+	// Fill the cache; older nonces must not be evicted by newer ones.
+	old := stampedContext("nonce-1", time.Now())
+	if err := guard.check(old); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := guard.check(stampedContext("nonce-2", time.Now())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := guard.check(stampedContext("nonce-3", time.Now()))
+	if status.Code(err) != codes.Unavailable || status.Convert(err).Message() != ErrReplayCacheFull.Error() {
+		t.Errorf("expected replay cache full error, got %v", err)
+	}
+	if err := guard.check(old); status.Convert(err).Message() != ErrReplayedRequest.Error() {
+		t.Errorf("expected replay of the oldest nonce to be rejected, got %v", err)
+	}
+}
+
+func TestNonceCachePrunesExpiredNonces(t *testing.T) {
+	cache := newNonceCache(2)
+	now := time.Now()
+
+	if err := cache.Add("nonce-1", now.Add(time.Second), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cache.Add("nonce-2", now.Add(time.Minute), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	later := now.Add(2 * time.Second)
+	if err := cache.Add("nonce-3", later.Add(time.Minute), later); err != nil {
+		t.Fatalf("expected expired nonce to make room, got %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 live nonces, got %d", cache.Len())
+	}
+	if err := cache.Add("nonce-2", later.Add(time.Minute), later); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("expected live nonce to be remembered, got %v", err)
+	}
+}
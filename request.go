@@ -0,0 +1,79 @@
+package aegis
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDMetadataKey carries the request ID on every mesh call.
+const requestIDMetadataKey = "x-aegis-request-id"
+
+// requestIDKey is the context key for the request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the given request ID.
+// An empty id generates a new one with NewID.
+// The ID is sent with every mesh call made with the context and is available
+// to handlers on the receiving node through RequestID, so a request can be
+// followed across hops.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		id = NewID()
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" if there is none.
+// Handlers of mesh RPCs always receive a request ID; one is generated if the
+// caller did not send it.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ensureRequestID returns ctx with a request ID, generating one if absent.
+func ensureRequestID(ctx context.Context) context.Context {
+	if RequestID(ctx) != "" {
+		return ctx
+	}
+	return WithRequestID(ctx, "")
+}
+
+// incomingRequestID returns ctx carrying the request ID from incoming metadata,
+// generating one if the caller did not send it.
+func incomingRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(requestIDMetadataKey); len(ids) > 0 {
+		return WithRequestID(ctx, ids[0])
+	}
+	return WithRequestID(ctx, "")
+}
+
+// outgoingRequestID attaches the context's request ID to the outgoing metadata,
+// generating one if absent.
+func outgoingRequestID(ctx context.Context) context.Context {
+	ctx = ensureRequestID(ctx)
+	return metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, RequestID(ctx))
+}
+
+// requestIDUnaryServerInterceptor makes the caller's request ID available to unary handlers.
+func requestIDUnaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(incomingRequestID(ctx), req)
+}
+
+// requestIDStreamServerInterceptor makes the caller's request ID available to stream handlers.
+func requestIDStreamServerInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: incomingRequestID(ss.Context())})
+}
+
+// requestIDUnaryClientInterceptor sends the request ID with each unary call.
+func requestIDUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+}
+
+// requestIDStreamClientInterceptor sends the request ID when a stream is created.
+func requestIDStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+}
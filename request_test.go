@@ -0,0 +1,81 @@
+package aegis
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-1")
+	if id := RequestID(ctx); id != "req-1" {
+		t.Errorf("expected req-1, got %q", id)
+	}
+
+	if id := RequestID(context.Background()); id != "" {
+		t.Errorf("expected no request ID, got %q", id)
+	}
+
+	if id := RequestID(WithRequestID(context.Background(), "")); len(id) != 32 {
+		t.Errorf("expected generated request ID, got %q", id)
+	}
+}
+
+func TestRequestIDPropagatesAcrossRPC(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	if err := requestIDUnaryClientInterceptor(ctx, "/aegis.MeshService/Ping", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var received string
+	handler := func(ctx context.Context, req any) (any, error) {
+		received = RequestID(ctx)
+		return nil, nil
+	}
+
+	serverCtx := metadata.NewIncomingContext(context.Background(), outgoing)
+	if _, err := requestIDUnaryServerInterceptor(serverCtx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received != "req-1" {
+		t.Errorf("expected req-1, got %q", received)
+	}
+}
+
+func TestRequestIDGeneratedWhenAbsent(t *testing.T) {
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	if err := requestIDUnaryClientInterceptor(context.Background(), "/aegis.MeshService/Ping", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := outgoing.Get(requestIDMetadataKey); len(ids) != 1 || ids[0] == "" {
+		t.Errorf("expected a generated request ID in metadata, got %v", ids)
+	}
+
+	var received string
+	handler := func(ctx context.Context, req any) (any, error) {
+		received = RequestID(ctx)
+		return nil, nil
+	}
+
+	if _, err := requestIDUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received == "" {
+		t.Error("expected handler to receive a generated request ID")
+	}
+}
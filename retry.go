@@ -0,0 +1,76 @@
+package aegis
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultRetryAttempts is the default number of attempts, including the first call.
+	DefaultRetryAttempts = 3
+	// DefaultRetryBackoff is the default wait before the first retry.
+	DefaultRetryBackoff = 100 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default upper bound on the wait between retries.
+	DefaultRetryMaxBackoff = 2 * time.Second
+)
+
+// RetryConfig configures retries of unary calls to peers.
+// Zero values select DefaultRetryAttempts, DefaultRetryBackoff and DefaultRetryMaxBackoff.
+type RetryConfig struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// withDefaults returns the config with zero values replaced by the defaults.
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultRetryAttempts
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = DefaultRetryBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	return c
+}
+
+// retryUnaryInterceptor retries unary calls that fail with a transient error,
+// doubling the wait between attempts up to MaxBackoff. It must be outermost in
+// the chain so each attempt gets a fresh nonce and passes the circuit breaker,
+// which fails retries fast once it opens. Streams are not retried.
+func retryUnaryInterceptor(config RetryConfig) grpc.UnaryClientInterceptor {
+	config = config.withDefaults()
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		// Every attempt belongs to the same request.
+		ctx = ensureRequestID(ctx)
+
+		backoff := config.Backoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !isTransientFailure(err) || attempt >= config.MaxAttempts || ctx.Err() != nil {
+				return err
+			}
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			}
+			backoff = min(2*backoff, config.MaxBackoff)
+		}
+	}
+}
+
+// SetRetry enables retries of unary calls to peers that fail with Unavailable
+// or DeadlineExceeded. Applies to peers added after the call.
+func (pm *PeerManager) SetRetry(config RetryConfig) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.retryConfig = &config
+}
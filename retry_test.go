@@ -0,0 +1,95 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryUnaryInterceptorRetriesTransientFailures(t *testing.T) {
+	interceptor := retryUnaryInterceptor(RetryConfig{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	var calls int
+	var ids []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		ids = append(ids, RequestID(ctx))
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+
+	if err := interceptor(context.Background(), "/aegis.MeshService/Ping", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	if ids[0] == "" || ids[1] != ids[0] || ids[2] != ids[0] {
+		t.Errorf("expected attempts to share a request ID, got %v", ids)
+	}
+}
+
+func TestRetryUnaryInterceptorStopsAtMaxAttempts(t *testing.T) {
+	interceptor := retryUnaryInterceptor(RetryConfig{MaxAttempts: 2, Backoff: time.Millisecond})
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.DeadlineExceeded, "timeout")
+	}
+
+	err := interceptor(context.Background(), "/aegis.MeshService/Ping", nil, nil, nil, invoker)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryUnaryInterceptorSkipsPermanentFailures(t *testing.T) {
+	interceptor := retryUnaryInterceptor(RetryConfig{Backoff: time.Millisecond})
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.ResourceExhausted, "too large")
+	}
+
+	if err := interceptor(context.Background(), "/aegis.MeshService/Ping", nil, nil, nil, invoker); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryUnaryInterceptorStopsOnCircuitOpen(t *testing.T) {
+	cb := newCircuitBreaker("peer-1", CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour})
+	retry := retryUnaryInterceptor(RetryConfig{MaxAttempts: 5, Backoff: time.Millisecond})
+	breaker := cb.unaryInterceptor
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+	chained := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return breaker(ctx, method, req, reply, cc, invoker, opts...)
+	}
+
+	err := retry(context.Background(), "/aegis.MeshService/Ping", nil, nil, nil, chained)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the open breaker to stop retries after 1 call, got %d", calls)
+	}
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
+// topologyStreamBatchSize is the number of nodes sent per SyncTopologyStream chunk.
+const topologyStreamBatchSize = 256
+
 // ServiceRegistrar is called to register additional gRPC services.
 type ServiceRegistrar func(*grpc.Server)
 
@@ -21,12 +28,18 @@ type MeshServer struct {
 	listener   net.Listener
 	tlsConfig  *TLSConfig
 	registrars []ServiceRegistrar
+
+	tracerProvider trace.TracerProvider
+	replayGuard    *replayGuard
+	drain          *drainState
+	maxMessageSize int
 }
 
 // NewMeshServer creates a new mesh server for the node.
 func NewMeshServer(node *Node) *MeshServer {
 	return &MeshServer{
-		node: node,
+		node:  node,
+		drain: &drainState{},
 	}
 }
 
@@ -35,6 +48,21 @@ func (ms *MeshServer) SetTLSConfig(tlsConfig *TLSConfig) {
 	ms.tlsConfig = tlsConfig
 }
 
+// SetTracerProvider enables tracing spans for incoming RPCs.
+// Must be called before Start.
+func (ms *MeshServer) SetTracerProvider(tp trace.TracerProvider) {
+	ms.tracerProvider = tp
+}
+
+// SetReplayProtection makes the server reject requests whose timestamp is more than
+// window away from the local clock, or whose nonce was already seen. At most
+// cacheSize nonces are remembered at once; further requests are rejected until
+// older nonces leave the window.
+// Must be called before Start.
+func (ms *MeshServer) SetReplayProtection(window time.Duration, cacheSize int) {
+	ms.replayGuard = newReplayGuard(window, cacheSize)
+}
+
 // RegisterService adds a service registrar to be called when the server starts.
 func (ms *MeshServer) RegisterService(r ServiceRegistrar) {
 	ms.registrars = append(ms.registrars, r)
@@ -57,9 +85,12 @@ func (ms *MeshServer) Start() error {
 	}
 
 	ms.listener = listener
+	ms.drain.reset()
 
 	creds := credentials.NewTLS(ms.tlsConfig.GetServerTLSConfig())
 	opts := []grpc.ServerOption{grpc.Creds(creds)}
+	opts = append(opts, messageSizeServerOptions(ms.maxMessageSize)...)
+	opts = append(opts, ms.interceptorOptions()...)
 
 	ms.server = grpc.NewServer(opts...)
 	RegisterMeshServiceServer(ms.server, ms)
@@ -76,6 +107,27 @@ func (ms *MeshServer) Start() error {
 	return nil
 }
 
+// interceptorOptions returns the server options installing the interceptor chain.
+func (ms *MeshServer) interceptorOptions() []grpc.ServerOption {
+	unary := []grpc.UnaryServerInterceptor{ms.drain.unaryInterceptor, requestIDUnaryServerInterceptor}
+	stream := []grpc.StreamServerInterceptor{ms.drain.streamInterceptor, requestIDStreamServerInterceptor}
+
+	if ms.tracerProvider != nil {
+		unary = append(unary, tracingUnaryServerInterceptor(ms.tracerProvider))
+		stream = append(stream, tracingStreamServerInterceptor(ms.tracerProvider))
+	}
+
+	if ms.replayGuard != nil {
+		unary = append(unary, ms.replayGuard.unaryInterceptor)
+		stream = append(stream, ms.replayGuard.streamInterceptor)
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
 // Stop gracefully stops the gRPC server.
 func (ms *MeshServer) Stop() {
 	if ms.server != nil {
@@ -87,11 +139,18 @@ func (ms *MeshServer) Stop() {
 }
 
 // Ping responds to ping requests.
+// Peers with an unsupported protocol version are rejected with FailedPrecondition.
 func (ms *MeshServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	if mismatch := checkPeerVersion(req.SenderId, int(req.ProtocolVersion), MinProtocolVersion); mismatch != nil {
+		ms.node.notifyVersionMismatch(*mismatch)
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("%s: %s", ErrVersionMismatch, mismatch.Reason))
+	}
+
 	return &PingResponse{
-		ReceiverId: ms.node.ID,
-		Timestamp:  time.Now().Unix(),
-		Success:    true,
+		ReceiverId:      ms.node.ID,
+		Timestamp:       time.Now().Unix(),
+		Success:         true,
+		ProtocolVersion: ProtocolVersion,
 	}, nil
 }
 
@@ -104,17 +163,30 @@ func (ms *MeshServer) GetHealth(ctx context.Context, req *HealthRequest) (*Healt
 			LastChecked: 0,
 			Message:     "Health not initialized",
 			Error:       "",
+			Draining:    ms.IsDraining(),
 		}, nil
 	}
 
 	status, lastChecked, message, errMsg := ms.node.Health.Get()
 
+	history := ms.node.Health.History()
+	protoHistory := make([]*HealthStatusTransition, 0, len(history))
+	for _, t := range history {
+		protoHistory = append(protoHistory, &HealthStatusTransition{
+			Status:    string(t.Status),
+			Message:   t.Message,
+			Timestamp: t.Timestamp.Unix(),
+		})
+	}
+
 	return &HealthResponse{
 		NodeId:      ms.node.ID,
 		Status:      string(status),
 		LastChecked: lastChecked.Unix(),
 		Message:     message,
 		Error:       errMsg,
+		History:     protoHistory,
+		Draining:    ms.IsDraining(),
 	}, nil
 }
 
@@ -144,20 +216,100 @@ func (ms *MeshServer) SyncTopology(ctx context.Context, req *TopologySyncRequest
 		}, nil
 	}
 
-	nodes := ms.node.Topology.GetAllNodes()
-	protoNodes := make([]*TopologyNode, 0, len(nodes))
+	// Send only the changes when the requester shares our history and the
+	// change log still covers its version; otherwise fall back to a full sync.
+	if req.AcceptDelta {
+		changes, version, ok := ms.node.Topology.deltaFor(req.Lineage, req.Version)
+		if ok && len(changes) < ms.node.Topology.NodeCount() {
+			protoChanges := make([]*TopologyChange, 0, len(changes))
+			for _, change := range changes {
+				protoChanges = append(protoChanges, &TopologyChange{
+					Version: change.Version,
+					Type:    string(change.Type),
+					Node:    nodeInfoToProto(change.Node),
+				})
+			}
+
+			return &TopologySyncResponse{
+				Version:   version,
+				UpdatedAt: time.Now().Unix(),
+				Delta:     true,
+				Changes:   protoChanges,
+				Lineage:   req.Lineage,
+			}, nil
+		}
+	}
 
-	for _, node := range nodes {
+	snapshot := ms.node.Topology.Clone()
+
+	// Large snapshots may not fit in one message; have capable requesters stream them.
+	if req.AcceptStream && len(snapshot.Nodes) >= topologyStreamThreshold {
+		return &TopologySyncResponse{
+			Version:        snapshot.Version,
+			UpdatedAt:      snapshot.UpdatedAt.Unix(),
+			Lineage:        snapshot.lineage,
+			StreamRequired: true,
+		}, nil
+	}
+
+	protoNodes := make([]*TopologyNode, 0, len(snapshot.Nodes))
+
+	for _, node := range snapshot.Nodes {
 		protoNodes = append(protoNodes, nodeInfoToProto(node))
 	}
 
 	return &TopologySyncResponse{
-		Version:   ms.node.Topology.GetVersion(),
-		UpdatedAt: ms.node.Topology.UpdatedAt.Unix(),
+		Version:   snapshot.Version,
+		UpdatedAt: snapshot.UpdatedAt.Unix(),
 		Nodes:     protoNodes,
+		Lineage:   snapshot.lineage,
 	}, nil
 }
 
+// SyncTopologyStream streams a topology snapshot in batches of topologyStreamBatchSize nodes.
+// The snapshot is taken once so every batch shares the same version.
+// If the requester already has this version, a single empty chunk is sent.
+func (ms *MeshServer) SyncTopologyStream(req *TopologySyncRequest, stream grpc.ServerStreamingServer[TopologySyncChunk]) error {
+	if ms.node.Topology == nil {
+		return stream.Send(&TopologySyncChunk{UpdatedAt: time.Now().Unix()})
+	}
+
+	snapshot := ms.node.Topology.Clone()
+	if req.Version >= snapshot.Version {
+		return stream.Send(&TopologySyncChunk{
+			Version:   snapshot.Version,
+			UpdatedAt: snapshot.UpdatedAt.Unix(),
+			Lineage:   snapshot.lineage,
+		})
+	}
+
+	ids := make([]string, 0, len(snapshot.Nodes))
+	for id := range snapshot.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for start := 0; start < len(ids) || start == 0; start += topologyStreamBatchSize {
+		end := min(start+topologyStreamBatchSize, len(ids))
+
+		protoNodes := make([]*TopologyNode, 0, end-start)
+		for _, id := range ids[start:end] {
+			protoNodes = append(protoNodes, nodeInfoToProto(snapshot.Nodes[id]))
+		}
+
+		if err := stream.Send(&TopologySyncChunk{
+			Version:   snapshot.Version,
+			UpdatedAt: snapshot.UpdatedAt.Unix(),
+			Nodes:     protoNodes,
+			Lineage:   snapshot.lineage,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetTopology returns the current topology.
 func (ms *MeshServer) GetTopology(ctx context.Context, req *GetTopologyRequest) (*GetTopologyResponse, error) {
 	if ms.node.Topology == nil {
@@ -180,6 +332,29 @@ func (ms *MeshServer) GetTopology(ctx context.Context, req *GetTopologyRequest)
 	}, nil
 }
 
+// Broadcast handles flooded broadcast messages relayed by peers.
+func (ms *MeshServer) Broadcast(ctx context.Context, req *BroadcastRequest) (*BroadcastResponse, error) {
+	return &BroadcastResponse{
+		ReceiverId: ms.node.ID,
+		Accepted:   ms.node.receiveBroadcast(ctx, req),
+	}, nil
+}
+
+// GetCapabilities returns the protocol version and optional features of this node.
+func (ms *MeshServer) GetCapabilities(ctx context.Context, req *CapabilitiesRequest) (*CapabilitiesResponse, error) {
+	caps := ms.node.Capabilities()
+	return &CapabilitiesResponse{
+		NodeId:          ms.node.ID,
+		ProtocolVersion: ProtocolVersion,
+		Features:        caps.Features,
+	}, nil
+}
+
+// GetLoad returns the current load of this node.
+func (ms *MeshServer) GetLoad(ctx context.Context, req *LoadRequest) (*LoadResponse, error) {
+	return loadToProto(ms.node.CurrentLoad()), nil
+}
+
 // nodeInfoToProto converts a NodeInfo to a TopologyNode proto message.
 func nodeInfoToProto(node NodeInfo) *TopologyNode {
 	protoServices := make([]*Service, 0, len(node.Services))
@@ -8,7 +8,6 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
-	"math/big"
 	"net"
 	"os"
 	"path/filepath"
@@ -20,6 +19,27 @@ type TLSConfig struct {
 	Certificate tls.Certificate
 	CertPool    *x509.CertPool
 	ServerName  string
+
+	// Negotiation parameters; zero values select the defaults.
+	MinVersion       uint16
+	CipherSuites     []uint16
+	CurvePreferences []tls.CurveID
+}
+
+// TLSConnectionInfo describes the TLS parameters negotiated with a peer.
+type TLSConnectionInfo struct {
+	Version     uint16
+	CipherSuite uint16
+}
+
+// VersionName returns the negotiated TLS version, e.g. "TLS 1.3".
+func (i TLSConnectionInfo) VersionName() string {
+	return tls.VersionName(i.Version)
+}
+
+// CipherSuiteName returns the negotiated cipher suite's standard name.
+func (i TLSConnectionInfo) CipherSuiteName() string {
+	return tls.CipherSuiteName(i.CipherSuite)
 }
 
 // LoadOrGenerateTLS loads existing certificates or generates new ones
@@ -140,9 +160,14 @@ func generateCA(certDir string) (*x509.Certificate, *rsa.PrivateKey, error) {
 		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
 	}
 
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Create CA certificate template
 	template := x509.Certificate{
-		SerialNumber: big.NewInt(1),
+		SerialNumber: serial,
 		Subject: pkix.Name{
 			Organization:  []string{"Aegis Mesh Network"},
 			Country:       []string{"US"},
@@ -231,9 +256,14 @@ func generateNodeCertificate(nodeID string, caCert *x509.Certificate, caKey *rsa
 		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
 	}
 
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Create certificate template
 	template := x509.Certificate{
-		SerialNumber: big.NewInt(time.Now().Unix()),
+		SerialNumber: serial,
 		Subject: pkix.Name{
 			CommonName:   nodeID,
 			Organization: []string{"Aegis Node"},
@@ -302,19 +332,91 @@ func savePrivateKey(filename string, key *rsa.PrivateKey) error {
 // GetServerTLSConfig returns TLS configuration for the server
 func (tc *TLSConfig) GetServerTLSConfig() *tls.Config {
 	return &tls.Config{
-		Certificates: []tls.Certificate{tc.Certificate},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    tc.CertPool,
-		MinVersion:   tls.VersionTLS12,
+		Certificates:     []tls.Certificate{tc.Certificate},
+		ClientAuth:       tls.RequireAndVerifyClientCert,
+		ClientCAs:        tc.CertPool,
+		MinVersion:       tc.minVersion(),
+		CipherSuites:     tc.cipherSuites(),
+		CurvePreferences: tc.curvePreferences(),
 	}
 }
 
 // GetClientTLSConfig returns TLS configuration for the client
 func (tc *TLSConfig) GetClientTLSConfig(serverName string) *tls.Config {
 	return &tls.Config{
-		Certificates: []tls.Certificate{tc.Certificate},
-		RootCAs:      tc.CertPool,
-		ServerName:   serverName,
-		MinVersion:   tls.VersionTLS12,
+		Certificates:     []tls.Certificate{tc.Certificate},
+		RootCAs:          tc.CertPool,
+		ServerName:       serverName,
+		MinVersion:       tc.minVersion(),
+		CipherSuites:     tc.cipherSuites(),
+		CurvePreferences: tc.curvePreferences(),
 	}
+}
+
+// GetIdentifyTLSConfig returns TLS configuration for connecting to a peer whose
+// node ID is not yet known. The certificate chain is verified against the mesh
+// CA but the name is not, so the node ID can be read from the certificate.
+func (tc *TLSConfig) GetIdentifyTLSConfig() *tls.Config {
+	config := tc.GetClientTLSConfig("")
+	// #nosec G402 -- the chain is verified in VerifyConnection; only the name check is skipped.
+	config.InsecureSkipVerify = true
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return fmt.Errorf("peer presented no certificate")
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range state.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+			Roots:         tc.CertPool,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		return err
+	}
+	return config
+}
+
+// minVersion returns the configured minimum TLS version, defaulting to TLS 1.2.
+func (tc *TLSConfig) minVersion() uint16 {
+	if tc.MinVersion == 0 {
+		return tls.VersionTLS12
+	}
+	return tc.MinVersion
+}
+
+// cipherSuites returns the configured TLS 1.2 cipher suites or the defaults.
+func (tc *TLSConfig) cipherSuites() []uint16 {
+	if len(tc.CipherSuites) == 0 {
+		return DefaultCipherSuites()
+	}
+	return tc.CipherSuites
+}
+
+// curvePreferences returns the configured key exchange curves or the defaults.
+func (tc *TLSConfig) curvePreferences() []tls.CurveID {
+	if len(tc.CurvePreferences) == 0 {
+		return DefaultCurvePreferences()
+	}
+	return tc.CurvePreferences
+}
+
+// DefaultCipherSuites returns the TLS 1.2 cipher suites used when none are
+// configured: ECDHE key exchange with AEAD ciphers only.
+// TLS 1.3 suites are not configurable and are always enabled.
+func DefaultCipherSuites() []uint16 {
+	return []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+	}
+}
+
+// DefaultCurvePreferences returns the key exchange curves used when none are configured.
+func DefaultCurvePreferences() []tls.CurveID {
+	return []tls.CurveID{tls.X25519MLKEM768, tls.X25519, tls.CurveP256, tls.CurveP384}
 }
\ No newline at end of file
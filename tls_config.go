@@ -44,6 +44,13 @@ type TLSOptions struct {
 	VerifyChain      bool
 	AllowExpired     bool
 	RequiredSANs     []string
+
+	// Negotiation options; empty values select secure defaults.
+	// MinVersion may be tls.VersionTLS12 or tls.VersionTLS13.
+	// CipherSuites applies to TLS 1.2 only and must not contain insecure suites.
+	MinVersion       uint16
+	CipherSuites     []uint16
+	CurvePreferences []tls.CurveID
 }
 
 // DefaultTLSOptions returns secure default options
@@ -60,14 +67,50 @@ func DefaultTLSOptions(nodeID string, certDir string) *TLSOptions {
 
 // LoadTLSConfig loads TLS configuration based on options
 func LoadTLSConfig(opts *TLSOptions) (*TLSConfig, error) {
+	if err := validateNegotiationOptions(opts); err != nil {
+		return nil, err
+	}
+
+	var config *TLSConfig
+	var err error
+
 	switch opts.Source {
 	case CertSourceFile:
-		return loadFromFiles(opts)
+		config, err = loadFromFiles(opts)
 	case CertSourceEnv:
-		return loadFromEnv(opts)
+		config, err = loadFromEnv(opts)
 	default:
 		return nil, fmt.Errorf("unsupported certificate source: %s", opts.Source)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	config.MinVersion = opts.MinVersion
+	config.CipherSuites = opts.CipherSuites
+	config.CurvePreferences = opts.CurvePreferences
+	return config, nil
+}
+
+// validateNegotiationOptions rejects TLS versions below 1.2 and unknown or insecure cipher suites.
+func validateNegotiationOptions(opts *TLSOptions) error {
+	switch opts.MinVersion {
+	case 0, tls.VersionTLS12, tls.VersionTLS13:
+	default:
+		return fmt.Errorf("unsupported minimum TLS version: %s", tls.VersionName(opts.MinVersion))
+	}
+
+	secure := make(map[uint16]bool)
+	for _, suite := range tls.CipherSuites() {
+		secure[suite.ID] = true
+	}
+	for _, id := range opts.CipherSuites {
+		if !secure[id] {
+			return fmt.Errorf("cipher suite %s is not allowed", tls.CipherSuiteName(id))
+		}
+	}
+
+	return nil
 }
 
 // loadFromFiles loads certificates from filesystem
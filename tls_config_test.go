@@ -0,0 +1,114 @@
+package aegis
+
+import (
+	"crypto/tls"
+	"net"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/credentials"
+)
+
+func TestTLSConfigNegotiationDefaults(t *testing.T) {
+	config := (&TLSConfig{}).GetServerTLSConfig()
+
+	if config.MinVersion != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2 minimum, got %s", tls.VersionName(config.MinVersion))
+	}
+	if !slices.Equal(config.CipherSuites, DefaultCipherSuites()) {
+		t.Errorf("expected default cipher suites, got %v", config.CipherSuites)
+	}
+	if !slices.Equal(config.CurvePreferences, DefaultCurvePreferences()) {
+		t.Errorf("expected default curves, got %v", config.CurvePreferences)
+	}
+}
+
+func TestLoadTLSConfigNegotiationOptions(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadOrGenerateTLS("node-a", dir); err != nil {
+		t.Fatalf("failed to generate certificates: %v", err)
+	}
+
+	opts := DefaultTLSOptions("node-a", dir)
+	opts.VerifyChain = false
+	opts.MinVersion = tls.VersionTLS13
+	opts.CurvePreferences = []tls.CurveID{tls.X25519}
+
+	config, err := LoadTLSConfig(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := config.GetClientTLSConfig("node-a")
+	if client.MinVersion != tls.VersionTLS13 {
+		t.Errorf("expected TLS 1.3 minimum, got %s", tls.VersionName(client.MinVersion))
+	}
+	if !slices.Equal(client.CurvePreferences, []tls.CurveID{tls.X25519}) {
+		t.Errorf("expected configured curves, got %v", client.CurvePreferences)
+	}
+}
+
+func TestLoadTLSConfigRejectsWeakOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*TLSOptions)
+	}{
+		{"old version", func(o *TLSOptions) { o.MinVersion = tls.VersionTLS11 }},
+		{"insecure suite", func(o *TLSOptions) { o.CipherSuites = []uint16{tls.TLS_RSA_WITH_RC4_128_SHA} }},
+		{"unknown suite", func(o *TLSOptions) { o.CipherSuites = []uint16{0xffff} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultTLSOptions("node-a", t.TempDir())
+			tt.modify(opts)
+			if _, err := LoadTLSConfig(opts); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestTLSHandshakeUsesConfiguredCipherSuite(t *testing.T) {
+	dir := t.TempDir()
+	server, err := LoadOrGenerateTLS("node-a", dir)
+	if err != nil {
+		t.Fatalf("failed to generate certificates: %v", err)
+	}
+	client, err := LoadOrGenerateTLS("node-b", dir)
+	if err != nil {
+		t.Fatalf("failed to generate certificates: %v", err)
+	}
+
+	suite := tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256
+	server.CipherSuites = []uint16{suite}
+
+	serverConfig := server.GetServerTLSConfig()
+	// Cipher suites only apply to TLS 1.2.
+	serverConfig.MaxVersion = tls.VersionTLS12
+
+	serverConn, clientConn := net.Pipe()
+	defer func() { _ = serverConn.Close() }()
+	defer func() { _ = clientConn.Close() }()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- tls.Server(serverConn, serverConfig).Handshake()
+	}()
+
+	conn := tls.Client(clientConn, client.GetClientTLSConfig("node-a"))
+	if err := conn.Handshake(); err != nil {
+		t.Fatalf("client handshake failed: %v", err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("server handshake failed: %v", err)
+	}
+
+	info := tlsConnectionInfo(credentials.TLSInfo{State: conn.ConnectionState()})
+	if info.CipherSuite != suite {
+		t.Errorf("expected %s, got %s", tls.CipherSuiteName(suite), info.CipherSuiteName())
+	}
+	if info.VersionName() != "TLS 1.2" {
+		t.Errorf("expected TLS 1.2, got %s", info.VersionName())
+	}
+}
@@ -17,12 +17,44 @@ type NodeInfo struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// maxTopologyChanges bounds the change log kept for delta sync.
+const maxTopologyChanges = 1024
+
+// NodeChangeType describes how a node changed in the topology.
+type NodeChangeType string
+
+const (
+	// NodeAdded indicates a node joined the topology.
+	NodeAdded NodeChangeType = "added"
+	// NodeUpdated indicates a node's information changed.
+	NodeUpdated NodeChangeType = "updated"
+	// NodeRemoved indicates a node left the topology.
+	NodeRemoved NodeChangeType = "removed"
+)
+
+// NodeChange records a single topology change and the version it produced.
+type NodeChange struct {
+	Version int64          `json:"version"`
+	Type    NodeChangeType `json:"type"`
+	Node    NodeInfo       `json:"node"`
+}
+
 // Topology maintains the mesh network topology.
 type Topology struct {
 	Nodes     map[string]NodeInfo `json:"nodes"`
 	Version   int64               `json:"version"`
 	UpdatedAt time.Time           `json:"updated_at"`
 	mu        sync.RWMutex
+
+	// lineage identifies the linear history the version numbers belong to.
+	// It is adopted from a peer on full sync and replaced when a node modifies
+	// a history it adopted, so deltas are only exchanged within one history.
+	lineage string
+	owned   bool
+
+	// changes holds recent changes; changeBase is the version before the oldest one.
+	changes    []NodeChange
+	changeBase int64
 }
 
 // NewTopology creates a new empty topology.
@@ -31,6 +63,8 @@ func NewTopology() *Topology {
 		Nodes:     make(map[string]NodeInfo),
 		Version:   0,
 		UpdatedAt: time.Now(),
+		lineage:   NewID(),
+		owned:     true,
 	}
 }
 
@@ -43,11 +77,13 @@ func (t *Topology) AddNode(info NodeInfo) error {
 		return fmt.Errorf("node %s already exists in topology", info.ID)
 	}
 
+	t.fork()
 	info.JoinedAt = time.Now()
 	info.UpdatedAt = info.JoinedAt
 	t.Nodes[info.ID] = info
 	t.Version++
 	t.UpdatedAt = time.Now()
+	t.recordChange(NodeChange{Version: t.Version, Type: NodeAdded, Node: info})
 
 	return nil
 }
@@ -57,13 +93,16 @@ func (t *Topology) RemoveNode(nodeID string) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	if _, exists := t.Nodes[nodeID]; !exists {
+	info, exists := t.Nodes[nodeID]
+	if !exists {
 		return fmt.Errorf("node %s not found in topology", nodeID)
 	}
 
+	t.fork()
 	delete(t.Nodes, nodeID)
 	t.Version++
 	t.UpdatedAt = time.Now()
+	t.recordChange(NodeChange{Version: t.Version, Type: NodeRemoved, Node: info})
 
 	return nil
 }
@@ -78,11 +117,13 @@ func (t *Topology) UpdateNode(info NodeInfo) error {
 		return fmt.Errorf("node %s not found in topology", info.ID)
 	}
 
+	t.fork()
 	info.JoinedAt = existing.JoinedAt
 	info.UpdatedAt = time.Now()
 	t.Nodes[info.ID] = info
 	t.Version++
 	t.UpdatedAt = time.Now()
+	t.recordChange(NodeChange{Version: t.Version, Type: NodeUpdated, Node: info})
 
 	return nil
 }
@@ -115,6 +156,13 @@ func (t *Topology) GetVersion() int64 {
 	return t.Version
 }
 
+// versionAndLineage returns the version and the lineage it belongs to.
+func (t *Topology) versionAndLineage() (int64, string) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.Version, t.lineage
+}
+
 // Clone creates a copy of the topology.
 func (t *Topology) Clone() *Topology {
 	t.mu.RLock()
@@ -124,6 +172,7 @@ func (t *Topology) Clone() *Topology {
 		Nodes:     make(map[string]NodeInfo),
 		Version:   t.Version,
 		UpdatedAt: t.UpdatedAt,
+		lineage:   t.lineage,
 	}
 
 	for k, v := range t.Nodes {
@@ -135,13 +184,18 @@ func (t *Topology) Clone() *Topology {
 
 // Merge merges another topology if it has a higher version.
 func (t *Topology) Merge(other *Topology) bool {
-	if other == nil || other.Version <= t.GetVersion() {
+	if other == nil {
 		return false
 	}
 
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// Checked under the lock so concurrent merges cannot replace a newer topology.
+	if other.Version <= t.Version {
+		return false
+	}
+
 	t.Nodes = make(map[string]NodeInfo)
 	for k, v := range other.Nodes {
 		t.Nodes[k] = v
@@ -149,9 +203,116 @@ func (t *Topology) Merge(other *Topology) bool {
 	t.Version = other.Version
 	t.UpdatedAt = other.UpdatedAt
 
+	// History before a full replacement is unknown, so deltas restart here.
+	t.lineage = other.lineage
+	t.owned = false
+	t.changes = nil
+	t.changeBase = other.Version
+
 	return true
 }
 
+// Delta returns the changes made after sinceVersion, oldest first.
+// Returns false if the change log no longer reaches back to sinceVersion,
+// in which case a full sync is required.
+func (t *Topology) Delta(sinceVersion int64) ([]NodeChange, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.delta(sinceVersion)
+}
+
+// deltaFor returns the changes after sinceVersion and the resulting version,
+// provided the requester's topology shares this topology's lineage.
+func (t *Topology) deltaFor(lineage string, sinceVersion int64) ([]NodeChange, int64, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if lineage != t.lineage {
+		return nil, 0, false
+	}
+	changes, ok := t.delta(sinceVersion)
+	return changes, t.Version, ok
+}
+
+// delta implements Delta. Callers must hold the lock.
+func (t *Topology) delta(sinceVersion int64) ([]NodeChange, bool) {
+	if sinceVersion < t.changeBase || sinceVersion > t.Version {
+		return nil, false
+	}
+
+	start := len(t.changes)
+	for i, change := range t.changes {
+		if change.Version > sinceVersion {
+			start = i
+			break
+		}
+	}
+
+	changes := make([]NodeChange, len(t.changes)-start)
+	copy(changes, t.changes[start:])
+	return changes, true
+}
+
+// applyDelta applies changes computed from fromVersion of the given lineage and
+// advances the topology to toVersion. A delta the topology has already caught up
+// with, e.g. through a concurrent sync, is a no-op. Fails without modifying the
+// topology if the local history has otherwise moved on since the delta was requested.
+func (t *Topology) applyDelta(lineage string, fromVersion, toVersion int64, changes []NodeChange, updatedAt time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.lineage == lineage && t.Version >= toVersion {
+		return nil
+	}
+
+	if t.lineage != lineage || t.Version != fromVersion {
+		return fmt.Errorf("topology version %d does not match delta base %d", t.Version, fromVersion)
+	}
+
+	for _, change := range changes {
+		switch change.Type {
+		case NodeAdded, NodeUpdated, NodeRemoved:
+		default:
+			return fmt.Errorf("unknown topology change type %q", change.Type)
+		}
+	}
+
+	for _, change := range changes {
+		if change.Type == NodeRemoved {
+			delete(t.Nodes, change.Node.ID)
+		} else {
+			t.Nodes[change.Node.ID] = change.Node
+		}
+		t.recordChange(change)
+	}
+
+	t.Version = toVersion
+	t.UpdatedAt = updatedAt
+	return nil
+}
+
+// fork starts a new lineage before a local change to an adopted history.
+// Callers must hold the lock.
+func (t *Topology) fork() {
+	if t.owned {
+		return
+	}
+	t.lineage = NewID()
+	t.owned = true
+	t.changes = nil
+	t.changeBase = t.Version
+}
+
+// recordChange appends to the change log, dropping the oldest entry when full.
+// Callers must hold the lock.
+func (t *Topology) recordChange(change NodeChange) {
+	if len(t.changes) >= maxTopologyChanges {
+		t.changeBase = t.changes[0].Version
+		t.changes = t.changes[1:]
+	}
+	t.changes = append(t.changes, change)
+}
+
 // NodeCount returns the number of nodes.
 func (t *Topology) NodeCount() int {
 	t.mu.RLock()
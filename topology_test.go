@@ -0,0 +1,128 @@
+package aegis
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTopologyDelta(t *testing.T) {
+	topology := NewTopology()
+	_ = topology.AddNode(NodeInfo{ID: "a"})
+	since := topology.GetVersion()
+
+	_ = topology.AddNode(NodeInfo{ID: "b"})
+	_ = topology.UpdateNode(NodeInfo{ID: "a", Name: "renamed"})
+	_ = topology.RemoveNode("b")
+
+	changes, ok := topology.Delta(since)
+	if !ok {
+		t.Fatal("expected delta to be available")
+	}
+
+	want := []NodeChangeType{NodeAdded, NodeUpdated, NodeRemoved}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d", len(want), len(changes))
+	}
+	for i, change := range changes {
+		if change.Type != want[i] {
+			t.Errorf("change %d: expected %s, got %s", i, want[i], change.Type)
+		}
+		if change.Version != since+int64(i)+1 {
+			t.Errorf("change %d: expected version %d, got %d", i, since+int64(i)+1, change.Version)
+		}
+	}
+}
+
+func TestTopologyDeltaOutOfRange(t *testing.T) {
+	topology := NewTopology()
+	for i := 0; i < maxTopologyChanges+10; i++ {
+		_ = topology.AddNode(NodeInfo{ID: NewID()})
+	}
+
+	if _, ok := topology.Delta(1); ok {
+		t.Error("expected delta to be unavailable once the change log is truncated")
+	}
+	if _, ok := topology.Delta(topology.GetVersion() + 1); ok {
+		t.Error("expected delta to be unavailable for a future version")
+	}
+	if changes, ok := topology.Delta(topology.GetVersion()); !ok || len(changes) != 0 {
+		t.Errorf("expected empty delta at current version, got %v, %v", changes, ok)
+	}
+}
+
+func TestTopologyApplyDelta(t *testing.T) {
+	source := NewTopology()
+	_ = source.AddNode(NodeInfo{ID: "a"})
+
+	replica := NewTopology()
+	replica.Merge(source.Clone())
+
+	since := replica.GetVersion()
+	_ = source.AddNode(NodeInfo{ID: "b"})
+	_ = source.RemoveNode("a")
+
+	changes, ok := source.Delta(since)
+	if !ok {
+		t.Fatal("expected delta to be available")
+	}
+
+	if err := replica.applyDelta(source.lineage, since, source.GetVersion(), changes, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if replica.GetVersion() != source.GetVersion() {
+		t.Errorf("expected version %d, got %d", source.GetVersion(), replica.GetVersion())
+	}
+	if _, exists := replica.GetNode("a"); exists {
+		t.Error("expected node a to be removed")
+	}
+	if _, exists := replica.GetNode("b"); !exists {
+		t.Error("expected node b to be added")
+	}
+
+	// A delta the replica has already caught up with is a no-op.
+	if err := replica.applyDelta(source.lineage, since, source.GetVersion(), changes, time.Now()); err != nil {
+		t.Errorf("expected caught-up delta to be ignored, got %v", err)
+	}
+
+	if err := replica.applyDelta(source.lineage, since, source.GetVersion()+1, changes, time.Now()); err == nil {
+		t.Error("expected stale delta to be rejected")
+	}
+}
+
+func TestTopologyConcurrentMergeKeepsNewest(t *testing.T) {
+	topology := NewTopology()
+
+	var wg sync.WaitGroup
+	for version := int64(2); version <= 50; version++ {
+		wg.Add(1)
+		go func(version int64) {
+			defer wg.Done()
+			other := NewTopology()
+			other.Version = version
+			topology.Merge(other)
+		}(version)
+	}
+	wg.Wait()
+
+	if topology.GetVersion() != 50 {
+		t.Errorf("expected newest version 50, got %d", topology.GetVersion())
+	}
+}
+
+func TestTopologyLocalChangeForksLineage(t *testing.T) {
+	source := NewTopology()
+	_ = source.AddNode(NodeInfo{ID: "a"})
+
+	replica := NewTopology()
+	replica.Merge(source.Clone())
+	if replica.lineage != source.lineage {
+		t.Fatal("expected merged topology to adopt the source lineage")
+	}
+
+	_ = replica.AddNode(NodeInfo{ID: "local"})
+	if replica.lineage == source.lineage {
+		t.Error("expected local change to fork the lineage")
+	}
+}
@@ -0,0 +1,152 @@
+package aegis
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// tracerName identifies spans created by aegis.
+const tracerName = "github.com/zoobz-io/aegis"
+
+// requestIDSpanAttribute is the span attribute holding the request ID.
+const requestIDSpanAttribute = "aegis.request_id"
+
+// traceContext propagates W3C trace context (traceparent/tracestate) over gRPC metadata.
+var traceContext = propagation.TraceContext{}
+
+// metadataCarrier adapts gRPC metadata to an OpenTelemetry TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// EnableTracing creates spans for mesh RPCs and propagates trace context to peers.
+func (n *Node) EnableTracing(tp trace.TracerProvider) {
+	n.tracerProvider = tp
+
+	if n.MeshServer != nil {
+		n.MeshServer.SetTracerProvider(tp)
+	}
+
+	if n.PeerManager != nil {
+		n.PeerManager.SetTracerProvider(tp)
+	}
+}
+
+// injectTraceContext starts a client span and writes its context into outgoing metadata.
+func injectTraceContext(ctx context.Context, tracer trace.Tracer, method string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), requestIDAttribute(ctx))
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	traceContext.Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// extractTraceContext reads trace context from incoming metadata and starts a server span.
+func extractTraceContext(ctx context.Context, tracer trace.Tracer, method string) (context.Context, trace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = traceContext.Extract(ctx, metadataCarrier(md))
+	}
+	return tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindServer), requestIDAttribute(ctx))
+}
+
+// requestIDAttribute tags a span with the context's request ID, if any.
+func requestIDAttribute(ctx context.Context) trace.SpanStartOption {
+	if id := RequestID(ctx); id != "" {
+		return trace.WithAttributes(attribute.String(requestIDSpanAttribute, id))
+	}
+	return trace.WithAttributes()
+}
+
+// endSpan records an error, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracingUnaryServerInterceptor creates a server span for each unary RPC.
+func tracingUnaryServerInterceptor(tp trace.TracerProvider) grpc.UnaryServerInterceptor {
+	tracer := tp.Tracer(tracerName)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := extractTraceContext(ctx, tracer, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endSpan(span, err)
+		return resp, err
+	}
+}
+
+// tracingStreamServerInterceptor creates a server span for each streaming RPC.
+func tracingStreamServerInterceptor(tp trace.TracerProvider) grpc.StreamServerInterceptor {
+	tracer := tp.Tracer(tracerName)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := extractTraceContext(ss.Context(), tracer, info.FullMethod)
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+		endSpan(span, err)
+		return err
+	}
+}
+
+// tracingUnaryClientInterceptor creates a client span and propagates it for each unary call.
+func tracingUnaryClientInterceptor(tp trace.TracerProvider) grpc.UnaryClientInterceptor {
+	tracer := tp.Tracer(tracerName)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := injectTraceContext(ctx, tracer, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		endSpan(span, err)
+		return err
+	}
+}
+
+// tracingStreamClientInterceptor propagates trace context on stream creation.
+// The span covers stream establishment only.
+func tracingStreamClientInterceptor(tp trace.TracerProvider) grpc.StreamClientInterceptor {
+	tracer := tp.Tracer(tracerName)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := injectTraceContext(ctx, tracer, method)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		endSpan(span, err)
+		return stream, err
+	}
+}
+
+// contextServerStream overrides the context of a server stream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,102 @@
+package aegis
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func testSpanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+func TestTracingPropagatesAcrossRPC(t *testing.T) {
+	tp := noop.NewTracerProvider()
+	sc := testSpanContext(t)
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), sc)
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	client := tracingUnaryClientInterceptor(tp)
+	if err := client(ctx, "/aegis.MeshService/Ping", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(outgoing.Get("traceparent")) == 0 {
+		t.Fatal("expected traceparent in outgoing metadata")
+	}
+
+	var received trace.SpanContext
+	handler := func(ctx context.Context, req any) (any, error) {
+		received = trace.SpanContextFromContext(ctx)
+		return nil, nil
+	}
+
+	server := tracingUnaryServerInterceptor(tp)
+	serverCtx := metadata.NewIncomingContext(context.Background(), outgoing)
+	info := &grpc.UnaryServerInfo{FullMethod: "/aegis.MeshService/Ping"}
+	if _, err := server(serverCtx, nil, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received.TraceID() != sc.TraceID() {
+		t.Errorf("expected trace ID %s, got %s", sc.TraceID(), received.TraceID())
+	}
+}
+
+func TestTracingPreservesOutgoingMetadata(t *testing.T) {
+	tp := noop.NewTracerProvider()
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), testSpanContext(t))
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-custom", "value")
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	client := tracingUnaryClientInterceptor(tp)
+	if err := client(ctx, "/aegis.MeshService/Ping", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := outgoing.Get("x-custom"); len(got) != 1 || got[0] != "value" {
+		t.Errorf("expected existing metadata to be preserved, got %v", got)
+	}
+}
+
+func TestNodeEnableTracing(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+	tp := noop.NewTracerProvider()
+
+	node.EnableTracing(tp)
+
+	if node.MeshServer.tracerProvider == nil {
+		t.Error("expected mesh server tracer provider to be set")
+	}
+	if node.PeerManager.tracerProvider == nil {
+		t.Error("expected peer manager tracer provider to be set")
+	}
+}
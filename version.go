@@ -0,0 +1,84 @@
+package aegis
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ProtocolVersion is the mesh protocol version implemented by this package.
+	ProtocolVersion = 1
+	// MinProtocolVersion is the oldest peer protocol version this node interoperates with.
+	MinProtocolVersion = 1
+)
+
+// ErrVersionMismatch is returned when a peer runs an incompatible protocol version.
+var ErrVersionMismatch = errors.New("incompatible protocol version")
+
+// VersionMismatch describes a peer whose protocol version is not supported.
+type VersionMismatch struct {
+	PeerID       string
+	LocalVersion int
+	PeerVersion  int
+	Reason       string
+}
+
+// versionMismatchError is returned by pings that fail version negotiation.
+// It wraps ErrVersionMismatch and carries the details for the mismatch handler.
+type versionMismatchError struct {
+	mismatch VersionMismatch
+}
+
+func (e *versionMismatchError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrVersionMismatch, e.mismatch.Reason)
+}
+
+func (e *versionMismatchError) Unwrap() error {
+	return ErrVersionMismatch
+}
+
+// VersionMismatchHandler is called when a peer with an incompatible version is detected.
+type VersionMismatchHandler func(mismatch VersionMismatch)
+
+// checkPeerVersion returns a mismatch if the peer version is older than minVersion.
+// Version 0 means the peer predates version negotiation and is accepted.
+// Newer peers are accepted; they are responsible for rejecting versions they no longer support.
+func checkPeerVersion(peerID string, version, minVersion int) *VersionMismatch {
+	if version == 0 || version >= minVersion {
+		return nil
+	}
+	return &VersionMismatch{
+		PeerID:       peerID,
+		LocalVersion: ProtocolVersion,
+		PeerVersion:  version,
+		Reason:       fmt.Sprintf("peer protocol version %d is older than minimum %d", version, minVersion),
+	}
+}
+
+// OnVersionMismatch sets the handler invoked when a peer with an incompatible
+// protocol version pings this node or is pinged by it.
+func (n *Node) OnVersionMismatch(handler VersionMismatchHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.versionHandler = handler
+}
+
+// notifyVersionMismatchError invokes the version mismatch handler if err
+// reports a version mismatch.
+func (n *Node) notifyVersionMismatchError(err error) {
+	var mismatchErr *versionMismatchError
+	if errors.As(err, &mismatchErr) {
+		n.notifyVersionMismatch(mismatchErr.mismatch)
+	}
+}
+
+// notifyVersionMismatch invokes the version mismatch handler, if set.
+func (n *Node) notifyVersionMismatch(mismatch VersionMismatch) {
+	n.mu.RLock()
+	handler := n.versionHandler
+	n.mu.RUnlock()
+
+	if handler != nil {
+		handler(mismatch)
+	}
+}
@@ -0,0 +1,105 @@
+package aegis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckPeerVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int
+		mismatch bool
+	}{
+		{"unversioned peer", 0, false},
+		{"too old", 1, true},
+		{"minimum", 2, false},
+		{"newer", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatch := checkPeerVersion("peer-1", tt.version, 2)
+			if (mismatch != nil) != tt.mismatch {
+				t.Fatalf("expected mismatch=%v, got %+v", tt.mismatch, mismatch)
+			}
+			if mismatch != nil && mismatch.PeerVersion != tt.version {
+				t.Errorf("expected peer version %d, got %d", tt.version, mismatch.PeerVersion)
+			}
+		})
+	}
+}
+
+func TestPingNegotiatesVersion(t *testing.T) {
+	local := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	remote := NewNode("remote", "Remote", NodeTypeGeneric, "localhost:9002")
+	connectLoopback(local, remote)
+
+	var mismatches int
+	local.OnVersionMismatch(func(VersionMismatch) { mismatches++ })
+	remote.OnVersionMismatch(func(VersionMismatch) { mismatches++ })
+
+	resp, err := local.PingPeer(context.Background(), remote.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ProtocolVersion != ProtocolVersion {
+		t.Errorf("expected protocol version %d, got %d", ProtocolVersion, resp.ProtocolVersion)
+	}
+
+	peer, _ := local.GetPeer(remote.ID)
+	if peer.Info.Version != ProtocolVersion {
+		t.Errorf("expected peer version %d to be recorded, got %d", ProtocolVersion, peer.Info.Version)
+	}
+	if mismatches != 0 {
+		t.Errorf("expected no version mismatches, got %d", mismatches)
+	}
+}
+
+func TestServerPingAcceptsUnversionedPeer(t *testing.T) {
+	node := NewNode("test-node", "Test Node", NodeTypeGeneric, "localhost:8080")
+
+	resp, err := node.MeshServer.Ping(context.Background(), &PingRequest{SenderId: "legacy"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Error("expected ping to succeed")
+	}
+}
+
+// rejectingClient answers pings the way a server that rejects this node's version does.
+type rejectingClient struct {
+	MeshServiceClient
+}
+
+func (c *rejectingClient) Ping(context.Context, *PingRequest, ...grpc.CallOption) (*PingResponse, error) {
+	return nil, status.Error(codes.FailedPrecondition, "incompatible protocol version: peer protocol version 1 is older than minimum 2")
+}
+
+func TestPingRejectedByPeerReportsVersionMismatch(t *testing.T) {
+	node := NewNode("local", "Local", NodeTypeGeneric, "localhost:9001")
+	node.PeerManager.peers["remote"] = &Peer{
+		Info:   PeerInfo{ID: "remote"},
+		Client: &rejectingClient{},
+	}
+
+	var mismatches []VersionMismatch
+	node.OnVersionMismatch(func(m VersionMismatch) { mismatches = append(mismatches, m) })
+
+	_, err := node.PingPeer(context.Background(), "remote")
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Fatalf("expected ErrVersionMismatch, got %v", err)
+	}
+	if len(mismatches) != 1 {
+		t.Fatalf("expected 1 version mismatch, got %d", len(mismatches))
+	}
+	if mismatches[0].PeerID != "remote" || mismatches[0].LocalVersion != ProtocolVersion {
+		t.Errorf("unexpected mismatch: %+v", mismatches[0])
+	}
+}